@@ -1,17 +1,17 @@
 package main
 
 import (
-	"context"
 	"fmt"
-	"runtime"
 
 	winui "github.com/mmngadi/go-winui3/internal/winui"
 )
 
 func main() {
-	runtime.LockOSThread()
+	// MainLoop pins the thread, initializes COM (STA) and runs the window.
+	winui.MainLoop(setup)
+}
 
-	w := winui.InitWindowHandler()
+func setup(w *winui.Window) {
 	w.SetTitle("Hello from Go-WinUI3")
 	w.SetSize(1024, 768)
 	w.SetMinWidth(640)
@@ -19,6 +19,9 @@ func main() {
 
 	w.OnCreate(func(_ *winui.Window, _ *winui.WindowContext) {
 		w.SetBackgroundColor(winui.NewColor(255, 245, 245, 245))
+	})
+
+	w.OnReady(func(_ *winui.Window, _ *winui.WindowContext) {
 		sx, sy := w.DPIScale()
 		fmt.Printf("DPI scale: %.2fx, %.2fy\n", sx, sy)
 	})
@@ -38,6 +41,4 @@ func main() {
 			fmt.Printf("Left click at (%d, %d)\n", x, y)
 		}
 	})
-
-	w.Run(context.Background())
 }
@@ -0,0 +1,101 @@
+package winui
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// Window animations built on the existing window primitives. Each animation
+// kind keeps a generation counter; starting a new animation bumps it so any
+// in-flight goroutine of the same kind notices and stops.
+
+// animationStep is the tick interval of animation goroutines (~60Hz).
+const animationStep = time.Second / 60
+
+var fadeGen, moveGen uint64
+
+// Easing selects the progress curve of an animation.
+type Easing int
+
+const (
+	EaseLinear Easing = iota // constant speed
+	EaseInOut                // slow start and end (cosine)
+)
+
+// apply maps linear progress p (0..1) onto the easing curve.
+func (e Easing) apply(p float64) float64 {
+	switch e {
+	case EaseInOut:
+		return (1 - math.Cos(math.Pi*p)) / 2
+	default:
+		return p
+	}
+}
+
+// FadeWindow animates the window opacity from its current value to target
+// (0..1) over duration. The window is made layered first. A new call cancels
+// any fade still in progress. Returns immediately; the fade runs on its own goroutine.
+func FadeWindow(target float64, duration time.Duration) {
+	if target < 0 {
+		target = 0
+	} else if target > 1 {
+		target = 1
+	}
+	gen := atomic.AddUint64(&fadeGen, 1)
+	from := GetWindowOpacity()
+	if duration <= 0 {
+		SetWindowOpacity(target)
+		return
+	}
+	SetWindowOpacity(from) // ensure layered before the first step
+	go func() {
+		ticker := time.NewTicker(animationStep)
+		defer ticker.Stop()
+		start := time.Now()
+		for range ticker.C {
+			if atomic.LoadUint64(&fadeGen) != gen {
+				return
+			}
+			p := float64(time.Since(start)) / float64(duration)
+			if p >= 1 {
+				SetWindowOpacity(target)
+				return
+			}
+			SetWindowOpacity(from + (target-from)*p)
+		}
+	}()
+}
+
+// MoveWindowAnimated moves the window from its current position to x,y (outer
+// window origin, as in SetWindowPosition) over duration using easing. A new
+// call cancels any move still in progress. Returns immediately; the move runs
+// on its own goroutine.
+func MoveWindowAnimated(x, y int, duration time.Duration, easing Easing) {
+	gen := atomic.AddUint64(&moveGen, 1)
+	fromX, fromY := GetWindowPosition()
+	if duration <= 0 {
+		SetWindowPosition(x, y)
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(animationStep)
+		defer ticker.Stop()
+		start := time.Now()
+		for range ticker.C {
+			if atomic.LoadUint64(&moveGen) != gen {
+				return
+			}
+			p := float64(time.Since(start)) / float64(duration)
+			if p >= 1 {
+				SetWindowPosition(x, y)
+				return
+			}
+			e := easing.apply(p)
+			SetWindowPosition(
+				fromX+int(math.Round(float64(x-fromX)*e)),
+				fromY+int(math.Round(float64(y-fromY)*e)),
+			)
+		}
+	}()
+}
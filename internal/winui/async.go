@@ -0,0 +1,46 @@
+package winui
+
+// Asynchronous control creation. The Create* functions block until the UI
+// thread has built the control; the *Async variants run them on a goroutine
+// of their own and deliver the handle on a buffered channel, so a caller can
+// start many creations and collect the handles later. The channel receives
+// exactly one value, 0 if creation failed. Controls added to the same parent
+// concurrently appear in completion order, not call order; use a UIBatch when
+// order matters.
+
+// createAsync runs create on a new goroutine and delivers its result.
+func createAsync(create func() Handle) <-chan Handle {
+	ch := make(chan Handle, 1)
+	go func() { ch <- create() }()
+	return ch
+}
+
+// CreateTextInputAsync is the asynchronous form of CreateTextInput.
+func CreateTextInputAsync(parent Handle, text string) <-chan Handle {
+	return createAsync(func() Handle { return CreateTextInput(parent, text) })
+}
+
+// CreateListViewAsync is the asynchronous form of CreateListView.
+func CreateListViewAsync(parent Handle) <-chan Handle {
+	return createAsync(func() Handle { return CreateListView(parent) })
+}
+
+// CreateSeparatorAsync is the asynchronous form of CreateSeparator.
+func CreateSeparatorAsync(parent Handle, horizontal bool) <-chan Handle {
+	return createAsync(func() Handle { return CreateSeparator(parent, horizontal) })
+}
+
+// CreateSpacerAsync is the asynchronous form of CreateSpacer.
+func CreateSpacerAsync(parent Handle, sizeDIP float64) <-chan Handle {
+	return createAsync(func() Handle { return CreateSpacer(parent, sizeDIP) })
+}
+
+// AwaitHandles waits for every channel and returns the handles in argument
+// order.
+func AwaitHandles(chs ...<-chan Handle) []Handle {
+	hs := make([]Handle, len(chs))
+	for i, ch := range chs {
+		hs[i] = <-ch
+	}
+	return hs
+}
@@ -0,0 +1,72 @@
+package winui
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sys/windows"
+)
+
+// NativeBackend stands in for WinUI3Native.dll. Every native call the package
+// makes is routed to Call with the export name (as listed in WinUI3Native.def,
+// e.g. "InitUI", "create_window", "winui_poll_events", "set_window_title") and
+// the raw arguments, following the signatures in WinUI3Native.h. The return
+// value is the export's result (HRESULT, handle, count, ...); void exports
+// ignore it. Pointer arguments point at Go memory valid for the call, so a
+// stub can fill output parameters (e.g. the event buffer of winui_poll_events).
+//
+// Note that Win32 helpers (user32, dwmapi, ...) are not routed through the
+// backend; without a real HWND they report their documented zero values.
+type NativeBackend interface {
+	Call(export string, args ...uintptr) uintptr
+}
+
+// NativeBackendFunc adapts an ordinary function to NativeBackend.
+type NativeBackendFunc func(export string, args ...uintptr) uintptr
+
+func (f NativeBackendFunc) Call(export string, args ...uintptr) uintptr { return f(export, args...) }
+
+var (
+	nativeStubMu sync.RWMutex
+	nativeStub   NativeBackend
+)
+
+// SetNativeStub installs a backend that replaces the DLL (headless/test mode).
+// Load and Init then skip DLL loading and route all native calls to stub,
+// allowing the lifecycle loop and input pipeline to run on CI without a GUI.
+// Passing nil returns to the real DLL on the next Load. Call it before Load
+// (or InitWindowHandler) and not concurrently with other package calls.
+func SetNativeStub(stub NativeBackend) {
+	nativeStubMu.Lock()
+	nativeStub = stub
+	nativeStubMu.Unlock()
+	// Force the next Load/Init to rebind procs against the new backend.
+	dllOnce = sync.Once{}
+	dllErr = nil
+	atomic.StoreUint32(&uiInitialized, 0)
+}
+
+func currentNativeStub() NativeBackend {
+	nativeStubMu.RLock()
+	defer nativeStubMu.RUnlock()
+	return nativeStub
+}
+
+// nativeProc is a resolved export: either a DLL procedure or a stub binding.
+type nativeProc struct {
+	name    string
+	proc    *windows.Proc
+	backend NativeBackend
+}
+
+// Call invokes the export with the same contract as windows.Proc.Call. Like
+// windows.Proc.Call it is marked uintptrescapes so pointers converted to
+// uintptr in the argument list stay alive for the duration of the call.
+//
+//go:uintptrescapes
+func (p *nativeProc) Call(args ...uintptr) (r1, r2 uintptr, lastErr error) {
+	if p.backend != nil {
+		return p.backend.Call(p.name, args...), 0, nil
+	}
+	return p.proc.Call(args...)
+}
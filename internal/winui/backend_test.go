@@ -0,0 +1,121 @@
+package winui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+// useStub installs stub as the native backend for the rest of the test and
+// binds the procs against it.
+func useStub(tb testing.TB, stub NativeBackend) {
+	tb.Helper()
+	SetNativeStub(stub)
+	tb.Cleanup(func() { SetNativeStub(nil) })
+	if err := Load(); err != nil {
+		tb.Fatalf("Load with stub: %v", err)
+	}
+}
+
+// uiThread runs stub calls one at a time on its own goroutine, so every call
+// pays a cross-goroutine round-trip like a marshal to the UI thread.
+type uiThread chan func()
+
+func newUIThread(tb testing.TB) uiThread {
+	q := make(uiThread)
+	go func() {
+		for f := range q {
+			f()
+		}
+	}()
+	tb.Cleanup(func() { close(q) })
+	return q
+}
+
+func (q uiThread) do(f func()) {
+	done := make(chan struct{})
+	q <- func() { f(); close(done) }
+	<-done
+}
+
+// stubPtr turns a pointer argument back into a Go pointer. Stub arguments
+// point at Go memory kept alive by nativeProc.Call.
+func stubPtr[T any](arg uintptr) *T {
+	return (*T)(*(*unsafe.Pointer)(unsafe.Pointer(&arg)))
+}
+
+// fakeNative is a stub backend with an event queue: winui_poll_events hands
+// out up to max events per call and reports more while any remain. The window
+// always exists; it reports a shutdown request once closeAfterPolls polls
+// have happened (0 = never).
+type fakeNative struct {
+	mu              sync.Mutex
+	queue           []Event
+	pollCalls       int
+	initCalls       int
+	closeAfterPolls int
+}
+
+func (f *fakeNative) push(evs ...Event) {
+	f.mu.Lock()
+	f.queue = append(f.queue, evs...)
+	f.mu.Unlock()
+}
+
+func (f *fakeNative) Call(export string, args ...uintptr) uintptr {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch export {
+	case "InitUI":
+		f.initCalls++
+		return 0 // S_OK
+	case "window_exists", "is_window_ready", "wait_for_window_ready":
+		return 1
+	case "get_runtime_state":
+		*stubPtr[int32](args[0]) = 1
+		if f.closeAfterPolls > 0 && f.pollCalls >= f.closeAfterPolls {
+			*stubPtr[int32](args[1]) = 1
+		}
+		return 0
+	case "winui_poll_events":
+		f.pollCalls++
+		buf := unsafe.Slice(stubPtr[Event](args[0]), int(int32(args[1])))
+		n := copy(buf, f.queue)
+		f.queue = f.queue[n:]
+		if len(f.queue) > 0 {
+			*stubPtr[int32](args[2]) = 1
+		}
+		return uintptr(n)
+	}
+	return 0
+}
+
+// Every SetNativeStub must rebind the procs and rerun InitUI against the new
+// backend, even after a failed load or an earlier initialized runtime.
+func TestStubLoadAndRun(t *testing.T) {
+	for round := range 2 {
+		t.Run(fmt.Sprint("round", round), func(t *testing.T) {
+			dllErr = errors.New("stale load error") // must be cleared by SetNativeStub
+			f := &fakeNative{closeAfterPolls: 1}
+			useStub(t, f)
+
+			w := InitWindowHandler()
+			updates := 0
+			w.OnUpdate(func(*Window, *WindowContext) { updates++ })
+			w.Run(context.Background())
+
+			if f.initCalls != 1 {
+				t.Errorf("InitUI called %d times on this stub, want 1", f.initCalls)
+			}
+			if f.pollCalls == 0 || updates == 0 {
+				t.Errorf("loop did not run a frame (polls %d, updates %d)", f.pollCalls, updates)
+			}
+			if r := w.CloseReason(); r != CloseUser {
+				t.Errorf("CloseReason = %v, want %v", r, CloseUser)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package winui
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Batched control operations. Every CreateTextInput or control setter is a
+// separate marshal to the UI thread (and creation blocks for the answer), so
+// building a form of N controls costs N round-trips. BatchUI records the
+// operations and applies them through apply_ui_batch in a single round-trip.
+
+// Batch op codes (UI_BATCH_* in WinUI3Native.h)
+const (
+	uiBatchCreateTextInput = 1
+	uiBatchSetVisible      = 2
+	uiBatchSetEnabled      = 3
+	uiBatchCreateListView  = 4
+	uiBatchListViewAddItem = 5
+)
+
+// uiBatchOp mirrors the native UIBatchOp struct.
+type uiBatchOp struct {
+	Op       int32
+	Ref      int32 // index of an earlier op, or -1 to use Handle
+	Handle   uintptr
+	Text     *uint16
+	Value    int32
+	reserved int32
+}
+
+var pApplyUIBatch *nativeProc
+
+// BatchControl refers to a control inside a batch: either an existing control
+// wrapped with UIBatch.Use or one created earlier in the same batch. Handle is
+// filled in once BatchUI returns (0 if the operation failed).
+type BatchControl struct {
+	Handle Handle
+	index  int // op index that produces the control, -1 for existing handles
+}
+
+// UIBatch records operations for BatchUI. It is only valid inside the BatchUI callback.
+type UIBatch struct {
+	ops     []uiBatchOp
+	targets []*BatchControl // control produced or targeted by each op
+}
+
+// Use wraps an existing control handle for use as a parent or target in the batch.
+func (b *UIBatch) Use(h Handle) *BatchControl { return &BatchControl{Handle: h, index: -1} }
+
+// ref returns the native Ref/Handle pair addressing c.
+func (b *UIBatch) ref(c *BatchControl) (int32, uintptr) {
+	if c == nil {
+		return -1, 0
+	}
+	if c.index >= 0 {
+		return int32(c.index), 0
+	}
+	return -1, uintptr(c.Handle)
+}
+
+func (b *UIBatch) add(op int32, target *BatchControl, text *uint16, value int32, result *BatchControl) {
+	ref, h := b.ref(target)
+	b.ops = append(b.ops, uiBatchOp{Op: op, Ref: ref, Handle: h, Text: text, Value: value})
+	b.targets = append(b.targets, result)
+}
+
+// CreateTextInput records the creation of a TextBox under parent.
+func (b *UIBatch) CreateTextInput(parent *BatchControl, text string) *BatchControl {
+	c := &BatchControl{index: len(b.ops)}
+	t16, _ := syscall.UTF16PtrFromString(text)
+	b.add(uiBatchCreateTextInput, parent, t16, 0, c)
+	return c
+}
+
+// CreateListView records the creation of a ListView under parent.
+func (b *UIBatch) CreateListView(parent *BatchControl) *BatchControl {
+	c := &BatchControl{index: len(b.ops)}
+	b.add(uiBatchCreateListView, parent, nil, 0, c)
+	return c
+}
+
+// ListViewAddItem records appending an item to the ListView c.
+func (b *UIBatch) ListViewAddItem(c *BatchControl, text string) {
+	t16, _ := syscall.UTF16PtrFromString(text)
+	b.add(uiBatchListViewAddItem, c, t16, 0, nil)
+}
+
+// SetVisible records a visibility change (see SetControlVisible).
+func (b *UIBatch) SetVisible(c *BatchControl, visible bool) {
+	b.add(uiBatchSetVisible, c, nil, int32(boolArg(visible)), nil)
+}
+
+// SetEnabled records an enabled-state change (see SetControlEnabled).
+func (b *UIBatch) SetEnabled(c *BatchControl, enabled bool) {
+	b.add(uiBatchSetEnabled, c, nil, int32(boolArg(enabled)), nil)
+}
+
+// BatchUI runs fn to record control operations and applies them in one
+// UI-thread round-trip, so a form with N controls costs 1 marshal instead of N.
+// When it returns, the Handle of every BatchControl created in fn is set.
+// Operations whose parent or target failed are skipped. If the DLL lacks
+// apply_ui_batch the operations fall back to the individual calls.
+//
+// Measured by counting native calls: a form of 100 text inputs takes 1
+// round-trip batched against 100 applied one by one. Each round-trip saved is a
+// dispatcher-queue marshal plus the wait for its result.
+func BatchUI(fn func(b *UIBatch)) {
+	if fn == nil {
+		return
+	}
+	b := &UIBatch{}
+	fn(b)
+	if len(b.ops) == 0 {
+		return
+	}
+	if pApplyUIBatch == nil {
+		b.applyEach()
+		return
+	}
+	out := make([]uintptr, len(b.ops))
+	pApplyUIBatch.Call(uintptr(unsafe.Pointer(&b.ops[0])), uintptr(len(b.ops)), uintptr(unsafe.Pointer(&out[0])))
+	runtime.KeepAlive(b.ops) // Text pointers are read by the native side
+	for i, c := range b.targets {
+		if c != nil {
+			c.Handle = Handle(out[i])
+		}
+	}
+}
+
+// applyEach replays the batch through the per-call wrappers.
+func (b *UIBatch) applyEach() {
+	results := make([]Handle, len(b.ops))
+	for i, op := range b.ops {
+		target := Handle(op.Handle)
+		if op.Ref >= 0 {
+			target = results[op.Ref]
+		}
+		if target == 0 {
+			continue
+		}
+		switch op.Op {
+		case uiBatchCreateTextInput:
+			results[i] = CreateTextInput(target, windows.UTF16PtrToString(op.Text))
+		case uiBatchSetVisible:
+			SetControlVisible(target, op.Value != 0)
+			results[i] = target
+		case uiBatchSetEnabled:
+			SetControlEnabled(target, op.Value != 0)
+			results[i] = target
+		case uiBatchCreateListView:
+			results[i] = CreateListView(target)
+		case uiBatchListViewAddItem:
+			ListViewAddItem(target, windows.UTF16PtrToString(op.Text))
+			results[i] = target
+		}
+		if c := b.targets[i]; c != nil {
+			c.Handle = results[i]
+		}
+	}
+}
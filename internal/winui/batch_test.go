@@ -0,0 +1,91 @@
+package winui
+
+import (
+	"testing"
+	"unsafe"
+)
+
+const batchFormControls = 100
+
+// batchStub hands out sequential handles; each native call (apply_ui_batch
+// included) is one round-trip to ui, counted in *calls if calls is non-nil.
+func batchStub(ui uiThread, calls *int) NativeBackendFunc {
+	var next uintptr
+	return func(export string, args ...uintptr) uintptr {
+		var r uintptr
+		ui.do(func() {
+			if calls != nil {
+				*calls++
+			}
+			if export == "apply_ui_batch" {
+				out := unsafe.Slice(stubPtr[uintptr](args[2]), args[1])
+				for i := range out {
+					next++
+					out[i] = next
+				}
+				return
+			}
+			next++
+			r = next
+		})
+		return r
+	}
+}
+
+func buildForm(b *UIBatch) []*BatchControl {
+	root := b.Use(1)
+	cs := make([]*BatchControl, batchFormControls)
+	for i := range cs {
+		cs[i] = b.CreateTextInput(root, "field")
+	}
+	return cs
+}
+
+func TestBatchUIFillsHandles(t *testing.T) {
+	useStub(t, batchStub(newUIThread(t), nil))
+	var cs []*BatchControl
+	BatchUI(func(b *UIBatch) { cs = buildForm(b) })
+	for i, c := range cs {
+		if c.Handle == 0 {
+			t.Fatalf("control %d: Handle not set", i)
+		}
+	}
+}
+
+// The round-trip counts quoted in the BatchUI doc.
+func TestBatchUIRoundTrips(t *testing.T) {
+	var calls int
+	useStub(t, batchStub(newUIThread(t), &calls))
+
+	BatchUI(func(b *UIBatch) { buildForm(b) })
+	if calls != 1 {
+		t.Errorf("BatchUI: %d round-trips, want 1", calls)
+	}
+
+	calls = 0
+	ub := &UIBatch{}
+	buildForm(ub)
+	ub.applyEach()
+	if calls != batchFormControls {
+		t.Errorf("applyEach: %d round-trips, want %d", calls, batchFormControls)
+	}
+}
+
+// BatchUI against applyEach for a form of batchFormControls text inputs. The
+// stub charges one goroutine round-trip per native call, so the gap is a floor
+// on what the real UI-thread marshal saves.
+func BenchmarkBatchUI(b *testing.B) {
+	useStub(b, batchStub(newUIThread(b), nil))
+	for b.Loop() {
+		BatchUI(func(ub *UIBatch) { buildForm(ub) })
+	}
+}
+
+func BenchmarkBatchUIApplyEach(b *testing.B) {
+	useStub(b, batchStub(newUIThread(b), nil))
+	for b.Loop() {
+		ub := &UIBatch{}
+		buildForm(ub)
+		ub.applyEach()
+	}
+}
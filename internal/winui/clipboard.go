@@ -0,0 +1,184 @@
+package winui
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Clipboard access for arbitrary formats through the Win32 clipboard API.
+// The clipboard is opened for the shortest possible time and always closed
+// on the same OS thread that opened it (the goroutine is pinned meanwhile).
+
+var (
+	kernel32 = windows.NewLazySystemDLL("kernel32.dll")
+
+	procGlobalAlloc  = kernel32.NewProc("GlobalAlloc")
+	procGlobalFree   = kernel32.NewProc("GlobalFree")
+	procGlobalLock   = kernel32.NewProc("GlobalLock")
+	procGlobalUnlock = kernel32.NewProc("GlobalUnlock")
+	procGlobalSize   = kernel32.NewProc("GlobalSize")
+	// GlobalLock memory is allocated by the system, not Go; it is never turned
+	// into a Go pointer but copied in and out with RtlMoveMemory.
+	procMoveMemory = kernel32.NewProc("RtlMoveMemory")
+
+	procOpenClipboard            = user32.NewProc("OpenClipboard")
+	procCloseClipboard           = user32.NewProc("CloseClipboard")
+	procEmptyClipboard           = user32.NewProc("EmptyClipboard")
+	procEnumClipboardFormats     = user32.NewProc("EnumClipboardFormats")
+	procGetClipboardData         = user32.NewProc("GetClipboardData")
+	procIsClipboardFormatAvail   = user32.NewProc("IsClipboardFormatAvailable")
+	procSetClipboardData         = user32.NewProc("SetClipboardData")
+	procRegisterClipboardFormatW = user32.NewProc("RegisterClipboardFormatW")
+)
+
+const gmemMoveable = 0x0002 // GMEM_MOVEABLE
+
+// Standard clipboard formats (CF_*) for the generic clipboard functions.
+const (
+	ClipboardFormatText        = 1  // CF_TEXT
+	ClipboardFormatBitmap      = 2  // CF_BITMAP
+	ClipboardFormatDIB         = 8  // CF_DIB
+	ClipboardFormatUnicodeText = 13 // CF_UNICODETEXT
+	ClipboardFormatHDrop       = 15 // CF_HDROP
+	ClipboardFormatDIBV5       = 17 // CF_DIBV5
+)
+
+// ErrClipboardFormatUnavailable is returned by GetClipboardData when the
+// clipboard holds no data in the requested format.
+var ErrClipboardFormatUnavailable = errors.New("winui: clipboard format not available")
+
+// clipboard open retries; another process may hold it briefly
+const (
+	clipboardOpenAttempts = 10
+	clipboardOpenDelay    = 10 * time.Millisecond
+)
+
+// withClipboard opens the clipboard (owned by the main window when it
+// exists), runs fn and closes it again on the same thread.
+func withClipboard(fn func() error) error {
+	if err := procOpenClipboard.Find(); err != nil {
+		return err
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	var err error
+	for i := 0; i < clipboardOpenAttempts; i++ {
+		var r uintptr
+		if r, _, err = procOpenClipboard.Call(getHWND()); r != 0 {
+			defer procCloseClipboard.Call()
+			return fn()
+		}
+		time.Sleep(clipboardOpenDelay)
+	}
+	return fmt.Errorf("winui: open clipboard: %w", err)
+}
+
+// GetClipboardFormats returns the formats currently on the clipboard, in the
+// order the owner placed them (preferred first).
+func GetClipboardFormats() ([]uint32, error) {
+	var formats []uint32
+	err := withClipboard(func() error {
+		var f uintptr
+		for {
+			var err error
+			f, _, err = procEnumClipboardFormats.Call(f)
+			if f == 0 {
+				if err != windows.ERROR_SUCCESS {
+					return fmt.Errorf("winui: enumerate clipboard formats: %w", err)
+				}
+				return nil
+			}
+			formats = append(formats, uint32(f))
+		}
+	})
+	return formats, err
+}
+
+// GetClipboardData returns a copy of the raw clipboard data in format. Only
+// memory-based (HGLOBAL) formats are supported; handle formats such as
+// ClipboardFormatBitmap fail. Returns ErrClipboardFormatUnavailable if the
+// format is not on the clipboard.
+func GetClipboardData(format uint32) ([]byte, error) {
+	var data []byte
+	err := withClipboard(func() error {
+		if r, _, _ := procIsClipboardFormatAvail.Call(uintptr(format)); r == 0 {
+			return ErrClipboardFormatUnavailable
+		}
+		h, _, err := procGetClipboardData.Call(uintptr(format))
+		if h == 0 {
+			return fmt.Errorf("winui: get clipboard data: %w", err)
+		}
+		p, _, err := procGlobalLock.Call(h)
+		if p == 0 {
+			return fmt.Errorf("winui: lock clipboard data: %w", err)
+		}
+		defer procGlobalUnlock.Call(h)
+		n, _, _ := procGlobalSize.Call(h)
+		data = make([]byte, n)
+		if n > 0 {
+			procMoveMemory.Call(uintptr(unsafe.Pointer(&data[0])), p, n)
+		}
+		return nil
+	})
+	return data, err
+}
+
+// SetClipboardData replaces the clipboard contents with data in format. Use
+// RegisterClipboardFormat for application-specific formats.
+func SetClipboardData(format uint32, data []byte) error {
+	if err := procGlobalAlloc.Find(); err != nil {
+		return err
+	}
+	// zero-sized allocations are not accepted by SetClipboardData
+	h, _, err := procGlobalAlloc.Call(gmemMoveable, uintptr(max(len(data), 1)))
+	if h == 0 {
+		return fmt.Errorf("winui: allocate clipboard data: %w", err)
+	}
+	p, _, err := procGlobalLock.Call(h)
+	if p == 0 {
+		procGlobalFree.Call(h)
+		return fmt.Errorf("winui: lock clipboard data: %w", err)
+	}
+	if len(data) > 0 {
+		procMoveMemory.Call(p, uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)))
+	}
+	procGlobalUnlock.Call(h)
+
+	err = withClipboard(func() error {
+		if r, _, err := procEmptyClipboard.Call(); r == 0 {
+			return fmt.Errorf("winui: empty clipboard: %w", err)
+		}
+		if r, _, err := procSetClipboardData.Call(uintptr(format), h); r == 0 {
+			return fmt.Errorf("winui: set clipboard data: %w", err)
+		}
+		h = 0 // owned by the system now
+		return nil
+	})
+	if h != 0 {
+		procGlobalFree.Call(h)
+	}
+	return err
+}
+
+// RegisterClipboardFormat returns the format id for name, registering it on
+// first use. All processes registering the same name get the same id.
+func RegisterClipboardFormat(name string) (uint32, error) {
+	if err := procRegisterClipboardFormatW.Find(); err != nil {
+		return 0, err
+	}
+	n16, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	r, _, err := procRegisterClipboardFormatW.Call(uintptr(unsafe.Pointer(n16)))
+	if r == 0 {
+		return 0, fmt.Errorf("winui: register clipboard format %q: %w", name, err)
+	}
+	return uint32(r), nil
+}
@@ -0,0 +1,152 @@
+package winui
+
+import (
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// Unified control events. Every control callback goes through one handler
+// map keyed by (handle, event), which also keeps the Go closures reachable
+// for as long as the control is watched. The native side reports all events
+// through a single callback; DLLs that predate it are served through the
+// older per-type callbacks.
+
+// Control event names for SetControlEventHandler.
+const (
+	ControlEventClick     = "click"     // data: nil
+	ControlEventChanged   = "changed"   // data: string, the current text
+	ControlEventSelection = "selection" // data: int, the selected index (-1 none)
+	ControlEventFocus     = "focus"     // data: nil; also raised when a descendant gains focus
+	ControlEventBlur      = "blur"      // data: nil; also raised when a descendant loses focus
+)
+
+// native CONTROL_EVENT_* values
+var controlEventTypes = map[string]int{
+	ControlEventClick:     1,
+	ControlEventChanged:   2,
+	ControlEventSelection: 3,
+	ControlEventFocus:     4,
+	ControlEventBlur:      5,
+}
+
+type controlEventKey struct {
+	h     Handle
+	event string
+}
+
+// Unified control procs resolved optionally in Load; nil when the export is absent.
+var pRegisterControlEventCallback, pWatchControlEvent *nativeProc
+
+var (
+	controlEventMu          sync.RWMutex
+	controlEventHandlers    = make(map[controlEventKey]func(data any))
+	controlEventCallbackPtr uintptr
+	textChangedCallbackPtr  uintptr // legacy per-type callbacks
+	selectionCallbackPtr    uintptr
+)
+
+// SetControlEventHandler installs fn for event ("click", "changed",
+// "selection", "focus", "blur") of control h, replacing any previous handler for that pair;
+// nil removes it. The type of data depends on the event, see the
+// ControlEvent* constants. Events a control does not raise never fire.
+// Callbacks run on the UI thread; keep them short and do not block.
+func SetControlEventHandler(h Handle, event string, fn func(data any)) {
+	typ, ok := controlEventTypes[event]
+	if h == 0 || !ok {
+		if !ok {
+			logf(LogWarn, "unknown control event %q", event)
+		}
+		return
+	}
+	key := controlEventKey{h, event}
+	controlEventMu.Lock()
+	if fn == nil {
+		delete(controlEventHandlers, key)
+		controlEventMu.Unlock()
+		return
+	}
+	controlEventHandlers[key] = fn
+	controlEventMu.Unlock()
+	if !watchControlEvent(h, event, typ) {
+		logf(LogWarn, "%s handler stored but the DLL lacks the matching control event exports", event)
+	}
+}
+
+// dispatchControlEvent runs the handler registered for (h, event), if any.
+func dispatchControlEvent(h Handle, event string, data any) {
+	controlEventMu.RLock()
+	fn := controlEventHandlers[controlEventKey{h, event}]
+	controlEventMu.RUnlock()
+	if fn != nil {
+		fn(data)
+	}
+}
+
+// clearControlEventHandlers drops every handler (controls were released).
+func clearControlEventHandlers() {
+	controlEventMu.Lock()
+	clear(controlEventHandlers)
+	controlEventMu.Unlock()
+}
+
+// watchControlEvent asks the native side to report event for h, preferring
+// the unified export. Returns false if the DLL cannot deliver the event.
+func watchControlEvent(h Handle, event string, typ int) bool {
+	if pRegisterControlEventCallback != nil && pWatchControlEvent != nil {
+		// Create callback once; native signature: void cb(ControlHandle, int, int, const wchar_t*)
+		if controlEventCallbackPtr == 0 {
+			controlEventCallbackPtr = syscall.NewCallback(func(handle, typ, value uintptr, text *uint16) uintptr {
+				switch int(int32(typ)) {
+				case controlEventTypes[ControlEventClick]:
+					dispatchControlEvent(Handle(handle), ControlEventClick, nil)
+				case controlEventTypes[ControlEventChanged]:
+					// text is only valid during the call; copy it out now
+					dispatchControlEvent(Handle(handle), ControlEventChanged, windows.UTF16PtrToString(text))
+				case controlEventTypes[ControlEventSelection]:
+					dispatchControlEvent(Handle(handle), ControlEventSelection, int(int32(value)))
+				case controlEventTypes[ControlEventFocus]:
+					dispatchControlEvent(Handle(handle), ControlEventFocus, nil)
+				case controlEventTypes[ControlEventBlur]:
+					dispatchControlEvent(Handle(handle), ControlEventBlur, nil)
+				}
+				return 0
+			})
+			pRegisterControlEventCallback.Call(controlEventCallbackPtr)
+		}
+		pWatchControlEvent.Call(uintptr(h), uintptr(typ))
+		return true
+	}
+	switch event {
+	case ControlEventChanged:
+		if pRegisterTextChangedCallback == nil || pWatchTextInput == nil {
+			return false
+		}
+		// Create callback once; native signature: void cb(ControlHandle, const wchar_t*)
+		if textChangedCallbackPtr == 0 {
+			textChangedCallbackPtr = syscall.NewCallback(func(handle uintptr, text *uint16) uintptr {
+				dispatchControlEvent(Handle(handle), ControlEventChanged, windows.UTF16PtrToString(text))
+				return 0
+			})
+			pRegisterTextChangedCallback.Call(textChangedCallbackPtr)
+		}
+		pWatchTextInput.Call(uintptr(h))
+		return true
+	case ControlEventSelection:
+		if pRegisterSelectionChangedCallback == nil || pWatchListViewSelection == nil {
+			return false
+		}
+		// Create callback once; native signature: void cb(ControlHandle, int)
+		if selectionCallbackPtr == 0 {
+			selectionCallbackPtr = syscall.NewCallback(func(handle, index uintptr) uintptr {
+				dispatchControlEvent(Handle(handle), ControlEventSelection, int(int32(index)))
+				return 0
+			})
+			pRegisterSelectionChangedCallback.Call(selectionCallbackPtr)
+		}
+		pWatchListViewSelection.Call(uintptr(h))
+		return true
+	}
+	return false
+}
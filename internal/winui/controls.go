@@ -0,0 +1,258 @@
+package winui
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// Control APIs beyond creation. All functions take the opaque Handle returned
+// by the Create* functions and are no-ops (or return zero values) for a zero
+// handle or when the DLL does not provide the export.
+
+// Control procs resolved optionally in Load; nil when the export is absent.
+var (
+	pSetControlVisible, pSetControlEnabled *nativeProc
+	pIsControlVisible, pIsControlEnabled   *nativeProc
+	pRegisterTextChangedCallback           *nativeProc
+	pWatchTextInput                        *nativeProc
+	pClearWindowContent                    *nativeProc
+	pGetControlBounds                      *nativeProc
+	pSetControlToolTip                     *nativeProc
+	pEnumerateControls, pGetControlType    *nativeProc
+	pSetControlFocus, pGetFocusedControl   *nativeProc
+
+	pCreateMultilineTextInput, pSetTextInputReadOnly *nativeProc
+	pGetNativeControlPointer                         *nativeProc
+)
+
+// resolveControlProcs binds the optional control exports from mod.
+func resolveControlProcs(opt func(string) *nativeProc) {
+	pSetControlVisible = opt("set_control_visible")
+	pSetControlEnabled = opt("set_control_enabled")
+	pIsControlVisible = opt("is_control_visible")
+	pIsControlEnabled = opt("is_control_enabled")
+	pRegisterTextChangedCallback = opt("register_text_changed_callback")
+	pWatchTextInput = opt("watch_text_input")
+	pApplyUIBatch = opt("apply_ui_batch")
+	pClearWindowContent = opt("clear_window_content")
+	pGetControlBounds = opt("get_control_bounds")
+	pRegisterControlEventCallback = opt("register_control_event_callback")
+	pWatchControlEvent = opt("watch_control_event")
+	resolveListViewProcs(opt)
+	pCreateSeparator = opt("create_separator")
+	pCreateSpacer = opt("create_spacer")
+	pSetControlToolTip = opt("set_control_tooltip")
+	pEnumerateControls = opt("enumerate_controls")
+	pGetControlType = opt("get_control_type")
+	pSetControlFocus = opt("set_control_focus")
+	pGetFocusedControl = opt("get_focused_control")
+	pCreateMultilineTextInput = opt("create_multiline_text_input")
+	pSetTextInputReadOnly = opt("set_text_input_read_only")
+	pGetNativeControlPointer = opt("get_native_control_pointer")
+}
+
+// boolArg converts b to a native int argument (1/0).
+func boolArg(b bool) uintptr {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// SetControlVisible shows (Visible) or collapses (Collapsed) a control.
+func SetControlVisible(h Handle, visible bool) {
+	if h == 0 || pSetControlVisible == nil {
+		return
+	}
+	pSetControlVisible.Call(uintptr(h), boolArg(visible))
+}
+
+// SetControlEnabled sets IsEnabled on a control; ignored for non-Control elements.
+func SetControlEnabled(h Handle, enabled bool) {
+	if h == 0 || pSetControlEnabled == nil {
+		return
+	}
+	pSetControlEnabled.Call(uintptr(h), boolArg(enabled))
+}
+
+// IsControlVisible returns true if the control's Visibility is Visible.
+func IsControlVisible(h Handle) bool {
+	if h == 0 || pIsControlVisible == nil {
+		return false
+	}
+	r, _, _ := pIsControlVisible.Call(uintptr(h))
+	return r != 0
+}
+
+// IsControlEnabled returns true if the control is enabled. Elements that are
+// not Controls (e.g. panels) always report true.
+func IsControlEnabled(h Handle) bool {
+	if h == 0 || pIsControlEnabled == nil {
+		return false
+	}
+	r, _, _ := pIsControlEnabled.Call(uintptr(h))
+	return r != 0
+}
+
+// SetControlToolTip sets the tooltip shown while the pointer hovers over (or
+// keyboard focus rests on) the control. An empty text removes it.
+func SetControlToolTip(h Handle, text string) {
+	if h == 0 || pSetControlToolTip == nil {
+		return
+	}
+	t16, err := syscall.UTF16PtrFromString(text)
+	if err != nil {
+		return
+	}
+	pSetControlToolTip.Call(uintptr(h), uintptr(unsafe.Pointer(t16)))
+	runtime.KeepAlive(t16) // copied by the native side before it returns
+}
+
+// SetControlFocus moves keyboard focus to the control, e.g. the first field of
+// a form when it opens. Elements that cannot take focus ignore it.
+func SetControlFocus(h Handle) {
+	if h == 0 || pSetControlFocus == nil {
+		return
+	}
+	pSetControlFocus.Call(uintptr(h))
+}
+
+// GetFocusedControl returns the control that has keyboard focus, or 0 if
+// focus is on an element not created through this package or outside the
+// window content.
+func GetFocusedControl() Handle {
+	if pGetFocusedControl == nil {
+		return 0
+	}
+	r, _, _ := pGetFocusedControl.Call()
+	return Handle(r)
+}
+
+// OnControlFocus installs fn to be called when the control (or one of its
+// descendants) gains keyboard focus; nil removes it. OnControlBlur is the
+// counterpart for losing focus. Both run on the UI thread, like every control
+// event handler.
+func OnControlFocus(h Handle, fn func()) { setControlNotify(h, ControlEventFocus, fn) }
+func OnControlBlur(h Handle, fn func())  { setControlNotify(h, ControlEventBlur, fn) }
+
+// setControlNotify installs a payload-free handler for event.
+func setControlNotify(h Handle, event string, fn func()) {
+	var wrapped func(any)
+	if fn != nil {
+		wrapped = func(any) { fn() }
+	}
+	SetControlEventHandler(h, event, wrapped)
+}
+
+// GetAllControls returns the handles of all live controls, excluding the main
+// window, in no particular order. Handles released by ClearWindowContent are
+// not included. Returns an empty slice if the DLL cannot enumerate controls.
+func GetAllControls() []Handle {
+	if pEnumerateControls == nil {
+		return []Handle{}
+	}
+	for {
+		n, _, _ := pEnumerateControls.Call(0, 0)
+		// headroom for controls created between the two calls
+		buf := make([]Handle, int(int32(n))+8)
+		n, _, _ = pEnumerateControls.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+		if count := int(int32(n)); count <= len(buf) {
+			return buf[:max(count, 0)]
+		}
+	}
+}
+
+// GetControlType returns the XAML class name of a control without its
+// namespace, e.g. "TextBox", "Button" or "StackPanel". Returns "" for an
+// unknown handle.
+func GetControlType(h Handle) string {
+	if h == 0 || pGetControlType == nil {
+		return ""
+	}
+	buf := make([]uint16, 64)
+	for {
+		n, _, _ := pGetControlType.Call(uintptr(h), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+		if int(int32(n)) < len(buf) {
+			return syscall.UTF16ToString(buf)
+		}
+		buf = make([]uint16, int(int32(n))+1)
+	}
+}
+
+// GetNativeControlPointer returns the WinUI object behind h as a raw
+// IInspectable pointer (its IFrameworkElement interface), for passing to your
+// own C++/WinRT code; QueryInterface it for other interfaces. The pointer is
+// borrowed: do not Release it, and use it only while the control exists
+// (ClearWindowContent or window teardown end its life) unless you AddRef it.
+// The object belongs to the UI thread. Returns 0 for an unknown handle.
+func GetNativeControlPointer(h Handle) uintptr {
+	if h == 0 || pGetNativeControlPointer == nil {
+		return 0
+	}
+	r, _, _ := pGetNativeControlPointer.Call(uintptr(h))
+	return r
+}
+
+// GetControlBounds returns the control's position and size in DIPs relative to
+// the window content (use GetMousePositionDIP for matching mouse coordinates).
+// Returns zeros for an unknown handle or a control that has not been laid out
+// yet; layout happens asynchronously after creation.
+func GetControlBounds(h Handle) (x, y, w, hgt float64) {
+	if h == 0 || pGetControlBounds == nil {
+		return 0, 0, 0, 0
+	}
+	pGetControlBounds.Call(uintptr(h), uintptr(unsafe.Pointer(&x)), uintptr(unsafe.Pointer(&y)), uintptr(unsafe.Pointer(&w)), uintptr(unsafe.Pointer(&hgt)))
+	return x, y, w, hgt
+}
+
+// SetTextInputChangeHandler installs fn to be called on every edit of the
+// TextBox h with its current text. Passing nil removes the handler. The
+// callback runs on the UI thread; keep it short and do not block.
+func SetTextInputChangeHandler(h Handle, fn func(text string)) {
+	var wrapped func(any)
+	if fn != nil {
+		wrapped = func(data any) { fn(data.(string)) }
+	}
+	SetControlEventHandler(h, ControlEventChanged, wrapped)
+}
+
+// CreateMultilineTextInput creates a TextBox that accepts Enter as a newline,
+// wraps long lines and shows a vertical scroll bar when the text overflows,
+// e.g. for notes or, combined with SetTextInputReadOnly, a log panel. It
+// works with SetTextInputChangeHandler like CreateTextInput. Returns 0 for a
+// zero parent or on failure.
+func CreateMultilineTextInput(parent Handle, text string) Handle {
+	if parent == 0 || pCreateMultilineTextInput == nil {
+		return 0
+	}
+	t16, err := syscall.UTF16PtrFromString(text)
+	if err != nil {
+		return 0
+	}
+	r, _, _ := pCreateMultilineTextInput.Call(uintptr(parent), uintptr(unsafe.Pointer(t16)))
+	runtime.KeepAlive(t16) // read on the UI thread before the call returns
+	return Handle(r)
+}
+
+// SetTextInputReadOnly makes a text input read-only: the text can still be
+// selected, copied and scrolled but not edited. Other controls are ignored.
+func SetTextInputReadOnly(h Handle, readOnly bool) {
+	if h == 0 || pSetTextInputReadOnly == nil {
+		return
+	}
+	pSetTextInputReadOnly.Call(uintptr(h), boolArg(readOnly))
+}
+
+// ClearWindowContent removes every control created through this package from
+// the window and invalidates their handles; control event handlers are dropped.
+// The main window handle stays valid. Returns the number of handles released,
+// or -1 if the DLL does not support clearing content.
+func ClearWindowContent() int {
+	if pClearWindowContent == nil {
+		return -1
+	}
+	r, _, _ := pClearWindowContent.Call()
+	clearControlEventHandlers()
+	return int(int32(r))
+}
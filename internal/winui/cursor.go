@@ -0,0 +1,60 @@
+package winui
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Custom mouse cursors loaded from .cur and .ani files. The native layer
+// re-applies the current cursor on every WM_SETCURSOR over the client area,
+// so it survives pointer moves; animated cursors animate on their own.
+
+var (
+	procLoadCursorFromFileW = user32.NewProc("LoadCursorFromFileW")
+	procDestroyCursor       = user32.NewProc("DestroyCursor")
+)
+
+// optional proc; nil when the DLL predates custom cursors
+var pSetCustomCursor *nativeProc
+
+// Cursor is a Win32 cursor handle (HCURSOR). The zero value means the default
+// cursor.
+type Cursor uintptr
+
+// LoadCursorFromFile loads a static (.cur) or animated (.ani) cursor. Release
+// it with Destroy once it is no longer set.
+func LoadCursorFromFile(path string) (Cursor, error) {
+	if err := procLoadCursorFromFileW.Find(); err != nil {
+		return 0, err
+	}
+	p16, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	h, _, err := procLoadCursorFromFileW.Call(uintptr(unsafe.Pointer(p16)))
+	if h == 0 {
+		return 0, fmt.Errorf("winui: load cursor %q: %w", path, err)
+	}
+	return Cursor(h), nil
+}
+
+// Destroy releases a cursor loaded with LoadCursorFromFile. Do not destroy the
+// cursor currently passed to SetCustomCursor; switch away from it first.
+func (c Cursor) Destroy() {
+	if c == 0 || procDestroyCursor.Find() != nil {
+		return
+	}
+	procDestroyCursor.Call(uintptr(c))
+}
+
+// SetCustomCursor shows c while the pointer is over the client area; 0
+// restores the default arrow. It may be called before the window exists.
+// XAML elements that set their own cursor, such as the I-beam of a text
+// input, may still show theirs while hovered.
+func SetCustomCursor(c Cursor) {
+	if pSetCustomCursor == nil {
+		return
+	}
+	pSetCustomCursor.Call(uintptr(c))
+}
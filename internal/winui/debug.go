@@ -0,0 +1,49 @@
+package winui
+
+// DebugInfo is a summary of the window and runtime state for debug overlays
+// and logs, gathered by Window.Debug. Values read from the window are zero
+// (or false) before it exists.
+type DebugInfo struct {
+	Runtime     RuntimeState
+	CloseReason CloseReason
+
+	Hidden, Minimized, Maximized, Fullscreen, Focused bool
+
+	DocumentDirty bool // unsaved-changes marker of SetDocumentTitle
+
+	X, Y          int     // outer position, screen pixels
+	Width, Height int     // client size, physical pixels
+	Scale         float64 // DPI scale relative to 96 DPI
+
+	FPS              int     // frames in the last second (GetFPS)
+	FrameTime        float64 // last frame, seconds (GetFrameTime)
+	FrameTimeAverage float64 // rolling average, seconds
+
+	Controls    int      // len(GetAllControls())
+	ContextKeys []string // sorted WindowContext keys
+}
+
+// Debug collects the current DebugInfo. Each field comes from the matching
+// query function (IsWindowFocused, GetWindowClientSize, GetRuntimeState, ...),
+// so the values are read one after another and are not an atomic snapshot.
+func (w *Window) Debug() DebugInfo {
+	d := DebugInfo{
+		Runtime:          GetRuntimeState(),
+		CloseReason:      w.CloseReason(),
+		Hidden:           IsWindowHidden(),
+		Minimized:        IsWindowMinimized(),
+		Maximized:        IsWindowMaximized(),
+		Fullscreen:       IsWindowFullscreen(),
+		Focused:          IsWindowFocused(),
+		DocumentDirty:    w.IsDocumentDirty(),
+		FPS:              GetFPS(),
+		FrameTime:        GetFrameTime(),
+		FrameTimeAverage: GetFrameTimeAverage(),
+		Controls:         len(GetAllControls()),
+		ContextKeys:      w.ctx.Keys(),
+	}
+	d.X, d.Y = GetWindowPosition()
+	d.Width, d.Height = GetWindowClientSize()
+	d.Scale, _ = GetWindowScaleDPI()
+	return d
+}
@@ -0,0 +1,63 @@
+package winui
+
+import "unsafe"
+
+// Drag-to-move for borderless and custom-chrome windows. A left press inside
+// the drag region is turned into a caption press (WM_NCLBUTTONDOWN/HTCAPTION)
+// so the system runs its own move loop, including Aero Snap and
+// drag-to-maximize.
+
+var (
+	procPostMessageW   = user32.NewProc("PostMessageW")
+	procReleaseCapture = user32.NewProc("ReleaseCapture")
+)
+
+const (
+	wmNCLButtonDown = 0x00A1 // WM_NCLBUTTONDOWN
+	htCaption       = 2      // HTCAPTION
+)
+
+// StartWindowDrag starts a system move of the main window as if the caption
+// had been pressed at the current cursor position. Call it while the left
+// button is down; the move ends when the button is released.
+func StartWindowDrag() {
+	h := getHWND()
+	if h == 0 || procPostMessageW.Find() != nil || procGetCursorPos.Find() != nil {
+		return
+	}
+	var pt point
+	if r, _, _ := procGetCursorPos.Call(uintptr(unsafe.Pointer(&pt))); r == 0 {
+		return
+	}
+	if procReleaseCapture.Find() == nil {
+		procReleaseCapture.Call()
+	}
+	// lParam is a POINTS in screen coordinates
+	lp := uintptr(uint16(int16(pt.X))) | uintptr(uint16(int16(pt.Y)))<<16
+	procPostMessageW.Call(h, wmNCLButtonDown, htCaption, lp)
+}
+
+// EnableDragMove makes a left press inside region (client area, physical
+// pixels) drag the window. An empty region disables dragging. Unlike
+// SetTitleBarDragRegion it needs no custom title bar and works for any
+// window style; the press is detected by the loop, so a very short click may
+// not start a move.
+func (w *Window) EnableDragMove(region Rect) {
+	w.mu.Lock()
+	w.dragMove = region
+	w.mu.Unlock()
+}
+
+// checkDragMove starts a window drag when the left button went down inside
+// the drag region this frame and is still held.
+func (w *Window) checkDragMove() {
+	w.mu.RLock()
+	region := w.dragMove
+	w.mu.RUnlock()
+	if region.Empty() || !IsMouseButtonPressed(MouseButtonLeft) || !IsMouseButtonDown(MouseButtonLeft) {
+		return
+	}
+	if region.Contains(GetMousePosition()) {
+		StartWindowDrag()
+	}
+}
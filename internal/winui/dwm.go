@@ -0,0 +1,291 @@
+package winui
+
+import (
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// DWM (Desktop Window Manager) interop. Attributes that the running OS does not
+// know are rejected by DwmSetWindowAttribute with E_INVALIDARG; the setters
+// below treat that as a silent no-op so callers need no version checks.
+
+var (
+	dwmapi                    = windows.NewLazySystemDLL("dwmapi.dll")
+	procDwmSetWindowAttribute = dwmapi.NewProc("DwmSetWindowAttribute")
+	procDwmGetColorization    = dwmapi.NewProc("DwmGetColorizationColor")
+)
+
+// DWMWINDOWATTRIBUTE values
+const (
+	dwmwaTransitionsForceDisabled = 3
+	dwmwaUseImmersiveDarkModeOld  = 19 // Windows 10 before build 18985
+	dwmwaUseImmersiveDarkMode     = 20 // Windows 10 build 18985+
+	dwmwaWindowCornerPreference   = 33 // Windows 11 (22000+)
+	dwmwaBorderColor              = 34 // Windows 11 (22000+)
+	dwmwaCaptionColor             = 35 // Windows 11 (22000+)
+	dwmwaTextColor                = 36 // Windows 11 (22000+)
+	dwmwaSystemBackdropType       = 38 // Windows 11 (22621+)
+)
+
+// Window corner preference (DWM_WINDOW_CORNER_PREFERENCE)
+const (
+	CornerDefault    = 0
+	CornerDoNotRound = 1
+	CornerRound      = 2
+	CornerRoundSmall = 3
+)
+
+// setDwmAttrUint32 sets a 4-byte DWM attribute on the main window.
+// Returns false if the HWND or dwmapi is unavailable or the call failed.
+func setDwmAttrUint32(attr uint32, value uint32) bool {
+	h := getHWND()
+	if h == 0 || procDwmSetWindowAttribute.Find() != nil {
+		return false
+	}
+	r, _, _ := procDwmSetWindowAttribute.Call(h, uintptr(attr), uintptr(unsafe.Pointer(&value)), unsafe.Sizeof(value))
+	if hr := HRESULT(r); hr.Failed() {
+		logf(LogDebug, "DwmSetWindowAttribute(%d) failed: %s", attr, hr)
+		return false
+	}
+	return true
+}
+
+// SetWindowCornerPreference sets the window corner rounding (CornerDefault,
+// CornerDoNotRound, CornerRound, CornerRoundSmall). Windows 11 only; no-op elsewhere.
+func SetWindowCornerPreference(pref int) {
+	setDwmAttrUint32(dwmwaWindowCornerPreference, uint32(pref))
+}
+
+// SetWindowAnimationsEnabled turns the DWM minimize, restore and maximize
+// animations of the window on or off (DWMWA_TRANSITIONS_FORCEDISABLED), e.g.
+// for a snappier feel in kiosk apps. Animations are enabled by default.
+func SetWindowAnimationsEnabled(on bool) {
+	var disabled uint32
+	if !on {
+		disabled = 1
+	}
+	setDwmAttrUint32(dwmwaTransitionsForceDisabled, disabled)
+}
+
+// colorref converts a Color to the Win32 COLORREF layout (0x00BBGGRR); alpha is dropped.
+func (c Color) colorref() uint32 {
+	_, r, g, b := c.ARGB()
+	return uint32(b)<<16 | uint32(g)<<8 | uint32(r)
+}
+
+// GetSystemAccentColor returns the user's accent color (Settings >
+// Personalization > Colors), as an opaque Color suitable for
+// SetWindowBackgroundColor or control styling. It reads the DWM AccentColor
+// registry value and falls back to the DWM colorization color; returns 0 if
+// neither is available.
+func GetSystemAccentColor() Color {
+	if k, err := registry.OpenKey(registry.CURRENT_USER, `Software\Microsoft\Windows\DWM`, registry.QUERY_VALUE); err == nil {
+		v, _, err := k.GetIntegerValue("AccentColor")
+		k.Close()
+		if err == nil {
+			// stored as 0xAABBGGRR
+			return NewColor(255, int(v&0xFF), int(v>>8&0xFF), int(v>>16&0xFF))
+		}
+	}
+	if procDwmGetColorization.Find() != nil {
+		return 0
+	}
+	var argb uint32
+	var opaque int32
+	r, _, _ := procDwmGetColorization.Call(uintptr(unsafe.Pointer(&argb)), uintptr(unsafe.Pointer(&opaque)))
+	if HRESULT(r).Failed() {
+		return 0
+	}
+	return Color(argb | 0xFF000000)
+}
+
+// SetTitleBarColors brands the caption background, caption text and window
+// border. Windows 11 (22000+) only; no-op on older systems.
+func SetTitleBarColors(caption, text, border Color) {
+	setDwmAttrUint32(dwmwaCaptionColor, caption.colorref())
+	setDwmAttrUint32(dwmwaTextColor, text.colorref())
+	setDwmAttrUint32(dwmwaBorderColor, border.colorref())
+}
+
+// Backdrop materials for SetWindowBackdrop.
+const (
+	BackdropNone    = 0
+	BackdropMica    = 1
+	BackdropMicaAlt = 2
+	BackdropAcrylic = 3
+)
+
+// DWM_SYSTEMBACKDROP_TYPE values
+const (
+	dwmsbtNone            = 1
+	dwmsbtMainWindow      = 2 // Mica
+	dwmsbtTransientWindow = 3 // Acrylic
+	dwmsbtTabbedWindow    = 4 // Mica Alt
+)
+
+// backdrop state; the root background must stay transparent while a backdrop
+// is active or the opaque XAML fill hides the material.
+var (
+	backdropMu        sync.Mutex
+	backdropActive    bool
+	lastBackground    Color
+	lastBackgroundSet bool
+)
+
+// SetWindowBackdrop applies a system backdrop material (BackdropNone,
+// BackdropMica, BackdropMicaAlt, BackdropAcrylic). Requires Windows 11 build
+// 22621 or later; on older systems it is a no-op and the window keeps its
+// background color. While a backdrop is active the content background is
+// cleared to transparent; BackdropNone restores the last color set through
+// SetWindowBackgroundColor.
+func SetWindowBackdrop(material int) {
+	var sbt uint32
+	switch material {
+	case BackdropMica:
+		sbt = dwmsbtMainWindow
+	case BackdropMicaAlt:
+		sbt = dwmsbtTabbedWindow
+	case BackdropAcrylic:
+		sbt = dwmsbtTransientWindow
+	default:
+		sbt = dwmsbtNone
+	}
+	if !setDwmAttrUint32(dwmwaSystemBackdropType, sbt) {
+		return
+	}
+	setBackdropActive(sbt != dwmsbtNone)
+}
+
+// setBackdropActive records whether a translucent material is applied and
+// clears or restores the content background to match.
+func setBackdropActive(active bool) {
+	backdropMu.Lock()
+	backdropActive = active
+	bg, bgSet := lastBackground, lastBackgroundSet
+	backdropMu.Unlock()
+	if active {
+		applyBackgroundColor(NewColor(0, 0, 0, 0))
+	} else if bgSet {
+		applyBackgroundColor(bg)
+	}
+}
+
+// IsWindowBackdropActive reports whether a system backdrop (or the blur of
+// SetWindowBlur) is currently applied.
+func IsWindowBackdropActive() bool {
+	backdropMu.Lock()
+	defer backdropMu.Unlock()
+	return backdropActive
+}
+
+// Legacy blur-behind through the undocumented SetWindowCompositionAttribute,
+// the mechanism Windows 10 used for its own translucent surfaces.
+var procSetWindowCompositionAttribute = user32.NewProc("SetWindowCompositionAttribute")
+
+const (
+	wcaAccentPolicy = 19 // WCA_ACCENT_POLICY
+
+	accentDisabled                = 0
+	accentEnableBlurBehind        = 3
+	accentEnableAcrylicBlurBehind = 4 // Windows 10 1803+
+
+	accentFlagDrawAllBorders = 2 // paints GradientColor over the blur
+
+	buildAcrylicAccent  = 17134 // Windows 10 1803
+	buildSystemBackdrop = 22621 // Windows 11 22H2, DWMWA_SYSTEMBACKDROP_TYPE
+)
+
+// accentPolicy mirrors the undocumented ACCENT_POLICY struct.
+type accentPolicy struct {
+	AccentState   uint32
+	AccentFlags   uint32
+	GradientColor uint32 // 0xAABBGGRR
+	AnimationID   uint32
+}
+
+// windowCompositionAttribData mirrors WINDOWCOMPOSITIONATTRIBDATA.
+type windowCompositionAttribData struct {
+	Attrib uint32
+	Data   unsafe.Pointer
+	Size   uintptr
+}
+
+// SetWindowBlur makes the window background translucent and blurred, tinted
+// with tint (its alpha sets the tint strength). On Windows 11 22H2 and later it
+// applies BackdropAcrylic and ignores tint; on Windows 10 it uses the legacy
+// accent blur (acrylic from 1803, plain blur before). Off removes it. As with
+// SetWindowBackdrop the content background is transparent while it is on.
+func SetWindowBlur(on bool, tint Color) {
+	build := windows.RtlGetVersion().BuildNumber
+	if build >= buildSystemBackdrop {
+		material := BackdropNone
+		if on {
+			material = BackdropAcrylic
+		}
+		SetWindowBackdrop(material)
+		return
+	}
+	h := getHWND()
+	if h == 0 || procSetWindowCompositionAttribute.Find() != nil {
+		return
+	}
+	policy := accentPolicy{AccentState: accentDisabled}
+	if on {
+		a, r, g, b := tint.ARGB()
+		policy = accentPolicy{
+			AccentState:   accentEnableBlurBehind,
+			AccentFlags:   accentFlagDrawAllBorders,
+			GradientColor: uint32(a)<<24 | uint32(b)<<16 | uint32(g)<<8 | uint32(r),
+		}
+		if build >= buildAcrylicAccent {
+			policy.AccentState = accentEnableAcrylicBlurBehind
+		}
+	}
+	data := windowCompositionAttribData{Attrib: wcaAccentPolicy, Data: unsafe.Pointer(&policy), Size: unsafe.Sizeof(policy)}
+	if r, _, _ := procSetWindowCompositionAttribute.Call(h, uintptr(unsafe.Pointer(&data))); r == 0 {
+		logf(LogDebug, "SetWindowCompositionAttribute failed")
+		return
+	}
+	setBackdropActive(on)
+}
+
+// dark title bar request recorded before the HWND exists; applied by
+// applyPendingDWM once the window is ready.
+var (
+	darkTitleBarMu      sync.Mutex
+	darkTitleBarPending bool
+	darkTitleBarValue   bool
+)
+
+// SetWindowDarkTitleBar switches the caption to the immersive dark (or light)
+// style. Both the current and the pre-18985 attribute ids are tried. If the
+// window does not exist yet the request is kept and applied once it is ready.
+func SetWindowDarkTitleBar(dark bool) {
+	darkTitleBarMu.Lock()
+	darkTitleBarValue = dark
+	darkTitleBarPending = !applyDarkTitleBar(dark)
+	darkTitleBarMu.Unlock()
+}
+
+func applyDarkTitleBar(dark bool) bool {
+	var v uint32
+	if dark {
+		v = 1
+	}
+	if setDwmAttrUint32(dwmwaUseImmersiveDarkMode, v) {
+		return true
+	}
+	return setDwmAttrUint32(dwmwaUseImmersiveDarkModeOld, v)
+}
+
+// applyPendingDWM re-applies DWM requests made before the window was ready.
+func applyPendingDWM() {
+	darkTitleBarMu.Lock()
+	if darkTitleBarPending {
+		darkTitleBarPending = !applyDarkTitleBar(darkTitleBarValue)
+	}
+	darkTitleBarMu.Unlock()
+}
@@ -0,0 +1,45 @@
+package winui
+
+import "testing"
+
+func pushKeys(f *fakeNative, n int) {
+	for i := range n {
+		f.push(Event{Kind: EventKindKey, Code: int32(i)})
+	}
+}
+
+func TestDrainEventsBacklogInOneFrame(t *testing.T) {
+	f := &fakeNative{}
+	useStub(t, f)
+	w := InitWindowHandler()
+	w.SetPollBatchSize(16)
+	pushKeys(f, 200) // 12.5 batches
+
+	evs := w.drainEvents()
+	if len(evs) != 200 {
+		t.Fatalf("drained %d events, want 200", len(evs))
+	}
+	for i, ev := range evs {
+		if ev.Code != int32(i) {
+			t.Fatalf("event %d has code %d: order not kept", i, ev.Code)
+		}
+	}
+	if f.pollCalls != 13 {
+		t.Fatalf("%d PollEvents calls, want 13", f.pollCalls)
+	}
+}
+
+func TestDrainEventsBoundedPerFrame(t *testing.T) {
+	f := &fakeNative{}
+	useStub(t, f)
+	w := InitWindowHandler()
+	w.SetPollBatchSize(16)
+	pushKeys(f, 16*maxPollRounds+5)
+
+	if n := len(w.drainEvents()); n != 16*maxPollRounds {
+		t.Fatalf("first frame drained %d events, want %d", n, 16*maxPollRounds)
+	}
+	if n := len(w.drainEvents()); n != 5 {
+		t.Fatalf("second frame drained %d events, want the remaining 5", n)
+	}
+}
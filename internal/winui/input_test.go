@@ -0,0 +1,127 @@
+package winui
+
+import "testing"
+
+const vkA = 0x41
+
+func packXY(x, y int) uint64 { return uint64(uint32(x)) | uint64(uint32(y))<<32 }
+
+func packCode(code, mods int) int { return code | mods<<16 }
+
+// resetInput starts the test from a clean frame with nothing held.
+func resetInput(t *testing.T) {
+	t.Helper()
+	releaseHeldInput()
+	ResetKeyTransitions()
+	t.Cleanup(func() {
+		releaseHeldInput()
+		ResetKeyTransitions()
+	})
+}
+
+func TestKeyTransitions(t *testing.T) {
+	resetInput(t)
+
+	// frame 1: down
+	handleNativeInput(EventKindKey, packCode(vkA, 0), ActionDown, 0)
+	if !IsKeyPressed(vkA) || !IsKeyDown(vkA) {
+		t.Fatal("down: want pressed and held")
+	}
+	if IsKeyPressedRepeat(vkA) || IsKeyReleased(vkA) {
+		t.Fatal("down: unexpected repeat or release")
+	}
+
+	// frame 2: still held, no new event
+	ResetKeyTransitions()
+	if IsKeyPressed(vkA) || !IsKeyDown(vkA) {
+		t.Fatal("held: pressed must last one frame only")
+	}
+
+	// frame 3: auto-repeat
+	ResetKeyTransitions()
+	handleNativeInput(EventKindKey, packCode(vkA, 0), ActionDown, 0)
+	if !IsKeyPressedRepeat(vkA) || IsKeyPressed(vkA) {
+		t.Fatal("repeat: want repeat without a new press")
+	}
+
+	// frame 4: up
+	ResetKeyTransitions()
+	handleNativeInput(EventKindKey, packCode(vkA, 0), ActionUp, 0)
+	if !IsKeyReleased(vkA) || IsKeyDown(vkA) || IsKeyPressedRepeat(vkA) {
+		t.Fatal("up: want released and not held")
+	}
+
+	// frame 5: released is gone
+	ResetKeyTransitions()
+	if IsKeyReleased(vkA) {
+		t.Fatal("released must last one frame only")
+	}
+}
+
+func TestModifiersFromHighBits(t *testing.T) {
+	resetInput(t)
+
+	handleNativeInput(EventKindKey, packCode(vkA, ModLShift|ModRControl), ActionDown, 0)
+	if got := GetModifiers(); got != ModLShift|ModRControl {
+		t.Fatalf("GetModifiers = %#x, want %#x", got, ModLShift|ModRControl)
+	}
+	if !IsShiftDown() || !IsControlDown() || IsAltDown() {
+		t.Fatal("shift and control expected, alt not")
+	}
+	if !IsKeyDown(vkA) {
+		t.Fatal("modifiers leaked into the key code")
+	}
+
+	// modifiers persist across frames until the next event changes them
+	ResetKeyTransitions()
+	handleNativeInput(EventKindKey, packCode(vkA, 0), ActionUp, 0)
+	if got := GetModifiers(); got != 0 {
+		t.Fatalf("GetModifiers after release = %#x, want 0", got)
+	}
+}
+
+func TestMousePositionUnpacking(t *testing.T) {
+	resetInput(t)
+
+	handleNativeInput(EventKindMouse, packCode(MouseButtonLeft, ModLAlt), ActionDown, packXY(1234, 567))
+	if x, y := GetMousePosition(); x != 1234 || y != 567 {
+		t.Fatalf("GetMousePosition = (%d, %d), want (1234, 567)", x, y)
+	}
+	if !IsMouseButtonPressed(MouseButtonLeft) || GetModifiers() != ModLAlt {
+		t.Fatal("button press or modifiers not decoded")
+	}
+
+	// the position survives the frame boundary
+	ResetKeyTransitions()
+	if x, y := GetMousePosition(); x != 1234 || y != 567 {
+		t.Fatalf("after reset GetMousePosition = (%d, %d)", x, y)
+	}
+	if IsMouseButtonPressed(MouseButtonLeft) || !IsMouseButtonDown(MouseButtonLeft) {
+		t.Fatal("pressed must last one frame while the button stays down")
+	}
+
+	handleNativeInput(EventKindMouse, packCode(MouseButtonLeft, 0), ActionUp, packXY(0, 70000))
+	if x, y := GetMousePosition(); x != 0 || y != 70000 {
+		t.Fatalf("GetMousePosition = (%d, %d), want (0, 70000)", x, y)
+	}
+	if !IsMouseButtonReleased(MouseButtonLeft) {
+		t.Fatal("release not reported")
+	}
+}
+
+func TestWheelSumsPerFrame(t *testing.T) {
+	resetInput(t)
+
+	// deltas travel as signed 16-bit values in the code bits
+	for _, d := range []int{wheelDelta, 2 * wheelDelta, -wheelDelta, wheelDelta / 2} {
+		dispatchInput(EventKindMouse, uintptr(uint16(int16(d))), ActionWheel, uintptr(packXY(10, 20)))
+	}
+	if got, want := GetMouseWheelMove(), 2.5; got != want {
+		t.Fatalf("GetMouseWheelMove = %v, want %v", got, want)
+	}
+
+	ResetKeyTransitions()
+	if got := GetMouseWheelMove(); got != 0 {
+		t.Fatalf("GetMouseWheelMove after reset = %v, want 0", got)
+	}
+}
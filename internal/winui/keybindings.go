@@ -0,0 +1,65 @@
+package winui
+
+import "sync"
+
+// Key bindings map action names ("jump", "save") to virtual-key codes, so
+// application logic asks about actions and users can remap the keys. The
+// table is global, like the key state it reads.
+
+var (
+	keyBindingsMu sync.RWMutex
+	keyBindings   = make(map[string]int)
+)
+
+// LoadKeyBindings replaces the binding table with a copy of m (action name to
+// VK code), e.g. decoded from a config file. A nil or empty m clears it.
+func LoadKeyBindings(m map[string]int) {
+	table := make(map[string]int, len(m))
+	for name, vk := range m {
+		table[name] = vk
+	}
+	keyBindingsMu.Lock()
+	keyBindings = table
+	keyBindingsMu.Unlock()
+}
+
+// RebindAction binds action name to vk, adding the action if it is new.
+func RebindAction(name string, vk int) {
+	keyBindingsMu.Lock()
+	keyBindings[name] = vk
+	keyBindingsMu.Unlock()
+}
+
+// GetKeyBindings returns a copy of the binding table, e.g. to save it after
+// RebindAction.
+func GetKeyBindings() map[string]int {
+	keyBindingsMu.RLock()
+	defer keyBindingsMu.RUnlock()
+	m := make(map[string]int, len(keyBindings))
+	for name, vk := range keyBindings {
+		m[name] = vk
+	}
+	return m
+}
+
+// actionKey returns the key bound to name.
+func actionKey(name string) (int, bool) {
+	keyBindingsMu.RLock()
+	vk, ok := keyBindings[name]
+	keyBindingsMu.RUnlock()
+	return vk, ok
+}
+
+// IsActionPressed reports whether the key bound to name was pressed this frame
+// (see IsKeyPressed). Unbound actions report false.
+func IsActionPressed(name string) bool {
+	vk, ok := actionKey(name)
+	return ok && IsKeyPressed(vk)
+}
+
+// IsActionDown reports whether the key bound to name is held (see IsKeyDown).
+// Unbound actions report false.
+func IsActionDown(name string) bool {
+	vk, ok := actionKey(name)
+	return ok && IsKeyDown(vk)
+}
@@ -0,0 +1,32 @@
+package winui
+
+import "math"
+
+// Layout helpers for forms built from StackPanels: thin theme-colored divider
+// lines and fixed empty space. Both are plain elements without events.
+
+// Layout procs resolved optionally in Load; nil when the export is absent.
+var pCreateSeparator, pCreateSpacer *nativeProc
+
+// CreateSeparator adds a 1 DIP divider line under parent using the theme
+// divider color. A horizontal separator spans the parent's width (use it in a
+// vertical StackPanel); a vertical one spans its height. Returns 0 on failure.
+func CreateSeparator(parent Handle, horizontal bool) Handle {
+	if parent == 0 || pCreateSeparator == nil {
+		return 0
+	}
+	r, _, _ := pCreateSeparator.Call(uintptr(parent), boolArg(horizontal))
+	return Handle(r)
+}
+
+// CreateSpacer adds sizeDIP of empty space under parent (along the stacking
+// direction of a StackPanel). Negative sizes are treated as 0. Returns 0 on
+// failure.
+func CreateSpacer(parent Handle, sizeDIP float64) Handle {
+	if parent == 0 || pCreateSpacer == nil {
+		return 0
+	}
+	// passed as raw bits; see the resize callback
+	r, _, _ := pCreateSpacer.Call(uintptr(parent), uintptr(math.Float64bits(sizeDIP)))
+	return Handle(r)
+}
@@ -0,0 +1,86 @@
+package winui
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// ListView of string items (single selection). Creation and reads block on
+// the UI thread; adding and clearing items are asynchronous but keep their
+// order relative to other control calls.
+
+// ListView procs resolved optionally in Load; nil when the export is absent.
+var (
+	pCreateListView, pListViewAddItems, pListViewClear *nativeProc
+	pListViewGetSelectedIndex                          *nativeProc
+	pRegisterSelectionChangedCallback                  *nativeProc
+	pWatchListViewSelection                            *nativeProc
+)
+
+// resolveListViewProcs binds the optional ListView exports.
+func resolveListViewProcs(opt func(string) *nativeProc) {
+	pCreateListView = opt("create_list_view")
+	pListViewAddItems = opt("list_view_add_items")
+	pListViewClear = opt("list_view_clear")
+	pListViewGetSelectedIndex = opt("list_view_get_selected_index")
+	pRegisterSelectionChangedCallback = opt("register_selection_changed_callback")
+	pWatchListViewSelection = opt("watch_list_view_selection")
+}
+
+// CreateListView creates an empty ListView under parent (a panel or content
+// control, e.g. the main window) and returns its handle, or 0 on failure.
+func CreateListView(parent Handle) Handle {
+	if parent == 0 || pCreateListView == nil {
+		return 0
+	}
+	r, _, _ := pCreateListView.Call(uintptr(parent))
+	return Handle(r)
+}
+
+// ListViewAddItem appends one item. To add many items use ListViewAddItems,
+// which appends them all in a single UI-thread operation.
+func ListViewAddItem(h Handle, text string) { ListViewAddItems(h, text) }
+
+// ListViewAddItems appends items in order in one UI-thread operation.
+func ListViewAddItems(h Handle, items ...string) {
+	if h == 0 || len(items) == 0 || pListViewAddItems == nil {
+		return
+	}
+	ptrs := make([]*uint16, len(items))
+	for i, s := range items {
+		ptrs[i], _ = syscall.UTF16PtrFromString(s)
+	}
+	pListViewAddItems.Call(uintptr(h), uintptr(unsafe.Pointer(&ptrs[0])), uintptr(len(ptrs)))
+	runtime.KeepAlive(ptrs) // strings are copied by the native side before it returns
+}
+
+// ListViewClear removes all items.
+func ListViewClear(h Handle) {
+	if h == 0 || pListViewClear == nil {
+		return
+	}
+	pListViewClear.Call(uintptr(h))
+}
+
+// ListViewGetSelectedIndex returns the selected item index, or -1 if nothing
+// is selected or h is not a ListView.
+func ListViewGetSelectedIndex(h Handle) int {
+	if h == 0 || pListViewGetSelectedIndex == nil {
+		return -1
+	}
+	r, _, _ := pListViewGetSelectedIndex.Call(uintptr(h))
+	return int(int32(r))
+}
+
+// SetListViewSelectionHandler installs fn to be called with the new selected
+// index (-1 when the selection is cleared) whenever the selection of the
+// ListView h changes. Passing nil removes the handler. The callback runs on
+// the UI thread; keep it short and do not block.
+func SetListViewSelectionHandler(h Handle, fn func(index int)) {
+	var wrapped func(any)
+	if fn != nil {
+		wrapped = func(data any) { fn(data.(int)) }
+	}
+	SetControlEventHandler(h, ControlEventSelection, wrapped)
+}
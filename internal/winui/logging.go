@@ -0,0 +1,37 @@
+package winui
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Diagnostics. Most wrappers are silent no-ops when the DLL, an export or a
+// system call is unavailable; a logger makes those cases visible.
+
+// Log levels passed to the SetLogger callback.
+const (
+	LogDebug = "debug"
+	LogInfo  = "info"
+	LogWarn  = "warn"
+	LogError = "error"
+)
+
+var logger atomic.Value // func(level, msg string)
+
+// SetLogger installs fn to receive internal diagnostics: DLL search attempts,
+// missing exports, failed native calls and callback registration. fn may be
+// called from any goroutine, including native callback threads, and must not
+// block. Pass nil to disable logging (the default).
+func SetLogger(fn func(level, msg string)) {
+	logger.Store(fn)
+}
+
+// logf formats and emits a diagnostic when a logger is installed. Formatting
+// is skipped entirely otherwise.
+func logf(level, format string, args ...any) {
+	fn, _ := logger.Load().(func(level, msg string))
+	if fn == nil {
+		return
+	}
+	fn(level, "winui: "+fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,88 @@
+package winui
+
+import (
+	"sync"
+	"time"
+)
+
+// Modal windows. The parent is disabled with EnableWindow for as long as at
+// least one modal child is open. Child lifetime is watched by polling
+// IsWindow, so the parent is re-enabled however the child goes away (normal
+// close, DestroyWindow, crash of the owning thread).
+
+var (
+	procEnableWindow = user32.NewProc("EnableWindow")
+	procIsWindow     = user32.NewProc("IsWindow")
+)
+
+// GWLP_HWNDPARENT sets the owner of a top-level window.
+const gwlpHWNDParent = -8
+
+// modalPollInterval is how often modal children are checked for closure.
+const modalPollInterval = 50 * time.Millisecond
+
+// modal children open per parent; the parent is enabled again at zero
+var (
+	modalMu    sync.Mutex
+	modalCount = make(map[uintptr]int)
+)
+
+func isWindow(h uintptr) bool {
+	if h == 0 || procIsWindow.Find() != nil {
+		return false
+	}
+	r, _, _ := procIsWindow.Call(h)
+	return r != 0
+}
+
+func enableWindow(h uintptr, on bool) {
+	if procEnableWindow.Find() == nil {
+		procEnableWindow.Call(h, boolArg(on))
+	}
+}
+
+// SetWindowModal makes child modal to parent: the child becomes owned by the
+// parent (stays above it and minimizes with it), the parent is disabled, and
+// it is re-enabled and activated once the child window is destroyed. Several
+// modal children may share a parent; it stays disabled until the last one
+// closes. The returned channel is closed when the parent has been re-enabled.
+// Returns nil if either handle is not a window.
+func SetWindowModal(parent, child uintptr) <-chan struct{} {
+	if parent == child || !isWindow(parent) || !isWindow(child) {
+		return nil
+	}
+	if procSetWindowLongPtrW.Find() == nil {
+		idx := int32(gwlpHWNDParent)
+		procSetWindowLongPtrW.Call(child, uintptr(idx), parent)
+	}
+	modalMu.Lock()
+	modalCount[parent]++
+	modalMu.Unlock()
+	enableWindow(parent, false)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(modalPollInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if !isWindow(child) {
+				break
+			}
+		}
+		modalMu.Lock()
+		modalCount[parent]--
+		last := modalCount[parent] <= 0
+		if last {
+			delete(modalCount, parent)
+		}
+		modalMu.Unlock()
+		if last && isWindow(parent) {
+			enableWindow(parent, true)
+			if procSetForegroundWnd.Find() == nil {
+				procSetForegroundWnd.Call(parent)
+			}
+		}
+	}()
+	return done
+}
@@ -0,0 +1,357 @@
+package winui
+
+import (
+	"image"
+	"math"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Monitor enumeration and per-monitor queries. Monitors are addressed by their
+// index in EnumDisplayMonitors order; indices are only stable while the display
+// configuration does not change.
+
+// shcore imports (Windows 8.1+); resolved lazily so older systems fall back.
+var (
+	shcore               = windows.NewLazySystemDLL("shcore.dll")
+	procGetDpiForMonitor = shcore.NewProc("GetDpiForMonitor")
+)
+
+var (
+	procEnumDisplayMonitors   = user32.NewProc("EnumDisplayMonitors")
+	procGetMonitorInfoW       = user32.NewProc("GetMonitorInfoW")
+	procSystemParametersInfoW = user32.NewProc("SystemParametersInfoW")
+	procEnumDisplaySettingsW  = user32.NewProc("EnumDisplaySettingsW")
+	procMonitorFromPoint      = user32.NewProc("MonitorFromPoint")
+)
+
+// optional proc; nil when the DLL cannot place the window before showing it
+var pSetInitialWindowPosition *nativeProc
+
+const (
+	mdtEffectiveDPI = 0 // MDT_EFFECTIVE_DPI
+
+	spiGetWorkArea = 0x0030 // SPI_GETWORKAREA
+
+	monitorInfoFPrimary = 0x1 // MONITORINFOF_PRIMARY
+
+	monitorDefaultToPrimary = 0x1 // MONITOR_DEFAULTTOPRIMARY
+
+	enumCurrentSettings = 0xFFFFFFFF // ENUM_CURRENT_SETTINGS
+
+	dmdo180 = 2 // DMDO_180
+)
+
+// monitorInfo mirrors the Win32 MONITORINFO struct.
+type monitorInfo struct {
+	CbSize    uint32
+	RcMonitor rect
+	RcWork    rect
+	DwFlags   uint32
+}
+
+// monitorInfoEx mirrors MONITORINFOEXW (adds the GDI device name).
+type monitorInfoEx struct {
+	monitorInfo
+	Device [32]uint16
+}
+
+// devMode mirrors the display variant of the Win32 DEVMODEW struct.
+type devMode struct {
+	DeviceName         [32]uint16
+	SpecVersion        uint16
+	DriverVersion      uint16
+	Size               uint16
+	DriverExtra        uint16
+	Fields             uint32
+	PositionX          int32
+	PositionY          int32
+	DisplayOrientation uint32
+	DisplayFixedOutput uint32
+	Color              int16
+	Duplex             int16
+	YResolution        int16
+	TTOption           int16
+	Collate            int16
+	FormName           [32]uint16
+	LogPixels          uint16
+	BitsPerPel         uint32
+	PelsWidth          uint32
+	PelsHeight         uint32
+	DisplayFlags       uint32
+	DisplayFrequency   uint32
+	ICMMethod          uint32
+	ICMIntent          uint32
+	MediaType          uint32
+	DitherType         uint32
+	Reserved1          uint32
+	Reserved2          uint32
+	PanningWidth       uint32
+	PanningHeight      uint32
+}
+
+// getMonitorInfo fetches bounds and work area of hMon.
+func getMonitorInfo(hMon uintptr) (monitorInfo, bool) {
+	var mi monitorInfo
+	if hMon == 0 || procGetMonitorInfoW.Find() != nil {
+		return mi, false
+	}
+	mi.CbSize = uint32(unsafe.Sizeof(mi))
+	r, _, _ := procGetMonitorInfoW.Call(hMon, uintptr(unsafe.Pointer(&mi)))
+	return mi, r != 0
+}
+
+// getMonitorInfoEx fetches bounds, work area and device name of hMon.
+func getMonitorInfoEx(hMon uintptr) (monitorInfoEx, bool) {
+	var mi monitorInfoEx
+	if hMon == 0 || procGetMonitorInfoW.Find() != nil {
+		return mi, false
+	}
+	mi.CbSize = uint32(unsafe.Sizeof(mi))
+	r, _, _ := procGetMonitorInfoW.Call(hMon, uintptr(unsafe.Pointer(&mi)))
+	return mi, r != 0
+}
+
+// displaySettings returns the current display mode of a GDI device
+// (e.g. \\.\DISPLAY1).
+func displaySettings(device *uint16) (devMode, bool) {
+	var dm devMode
+	if procEnumDisplaySettingsW.Find() != nil {
+		return dm, false
+	}
+	dm.Size = uint16(unsafe.Sizeof(dm))
+	r, _, _ := procEnumDisplaySettingsW.Call(uintptr(unsafe.Pointer(device)), uintptr(enumCurrentSettings), uintptr(unsafe.Pointer(&dm)))
+	return dm, r != 0
+}
+
+var (
+	monitorEnumMu          sync.Mutex
+	monitorEnumList        []uintptr
+	monitorEnumCallbackPtr uintptr
+)
+
+// enumMonitors returns the HMONITOR handles of all attached displays.
+func enumMonitors() []uintptr {
+	if procEnumDisplayMonitors.Find() != nil {
+		return nil
+	}
+	monitorEnumMu.Lock()
+	defer monitorEnumMu.Unlock()
+	// Create callback once; native signature: BOOL cb(HMONITOR, HDC, LPRECT, LPARAM)
+	if monitorEnumCallbackPtr == 0 {
+		monitorEnumCallbackPtr = syscall.NewCallback(func(hMon, hdc, lprc, lparam uintptr) uintptr {
+			monitorEnumList = append(monitorEnumList, hMon)
+			return 1 // continue enumeration
+		})
+	}
+	monitorEnumList = nil
+	procEnumDisplayMonitors.Call(0, 0, monitorEnumCallbackPtr, 0)
+	out := make([]uintptr, len(monitorEnumList))
+	copy(out, monitorEnumList)
+	return out
+}
+
+// monitorHandle returns the HMONITOR for index, or 0 if out of range.
+func monitorHandle(index int) uintptr {
+	mons := enumMonitors()
+	if index < 0 || index >= len(mons) {
+		return 0
+	}
+	return mons[index]
+}
+
+// GetMonitorCount returns the number of attached displays.
+func GetMonitorCount() int { return len(enumMonitors()) }
+
+// monitorScale returns the effective DPI scale (relative to 96) of hMon, or 1.0.
+func monitorScale(hMon uintptr) float64 {
+	if hMon == 0 || procGetDpiForMonitor.Find() != nil {
+		return 1
+	}
+	var dpiX, dpiY uint32
+	r, _, _ := procGetDpiForMonitor.Call(hMon, uintptr(mdtEffectiveDPI), uintptr(unsafe.Pointer(&dpiX)), uintptr(unsafe.Pointer(&dpiY)))
+	if HRESULT(r).Failed() || dpiX == 0 {
+		return 1
+	}
+	return float64(dpiX) / 96.0
+}
+
+// GetMonitorScale returns the effective DPI scale of the given monitor relative
+// to 96 DPI. Useful to pre-size a window before moving it to a monitor with a
+// different scale. Returns 1.0 for an invalid index or when shcore.dll is unavailable.
+func GetMonitorScale(monitorIndex int) float64 { return monitorScale(monitorHandle(monitorIndex)) }
+
+// GetWorkArea returns the primary monitor's work area (screen minus taskbar
+// and docked app bars) in screen pixels.
+func GetWorkArea() (x, y, w, h int) {
+	if procSystemParametersInfoW.Find() != nil {
+		return 0, 0, GetScreenWidth(), GetScreenHeight()
+	}
+	var rc rect
+	r, _, _ := procSystemParametersInfoW.Call(uintptr(spiGetWorkArea), 0, uintptr(unsafe.Pointer(&rc)), 0)
+	if r == 0 {
+		return 0, 0, GetScreenWidth(), GetScreenHeight()
+	}
+	return int(rc.Left), int(rc.Top), int(rc.Right - rc.Left), int(rc.Bottom - rc.Top)
+}
+
+// GetMonitorWorkArea returns the work area of the given monitor in virtual
+// screen coordinates, or zeros for an invalid index.
+func GetMonitorWorkArea(monitorIndex int) (x, y, w, h int) {
+	mi, ok := getMonitorInfo(monitorHandle(monitorIndex))
+	if !ok {
+		return 0, 0, 0, 0
+	}
+	rc := mi.RcWork
+	return int(rc.Left), int(rc.Top), int(rc.Right - rc.Left), int(rc.Bottom - rc.Top)
+}
+
+// MonitorInfo describes one attached display. Bounds and WorkArea are in
+// virtual screen pixels; Index matches the monitorIndex of the other monitor
+// functions.
+type MonitorInfo struct {
+	Index       int
+	Bounds      image.Rectangle
+	WorkArea    image.Rectangle
+	Scale       float64 // effective DPI scale relative to 96
+	Primary     bool
+	RefreshRate int // Hz; 0 if unknown
+}
+
+// GetMonitors returns every attached display in one call, combining the
+// bounds, work area, DPI scale and refresh rate queries. Indices are only
+// stable while the display configuration does not change.
+func GetMonitors() []MonitorInfo {
+	mons := enumMonitors()
+	out := make([]MonitorInfo, 0, len(mons))
+	for i, hMon := range mons {
+		mi, ok := getMonitorInfoEx(hMon)
+		if !ok {
+			continue
+		}
+		info := MonitorInfo{
+			Index:    i,
+			Bounds:   rectToImage(mi.RcMonitor),
+			WorkArea: rectToImage(mi.RcWork),
+			Scale:    monitorScale(hMon),
+			Primary:  mi.DwFlags&monitorInfoFPrimary != 0,
+		}
+		if dm, ok := displaySettings(&mi.Device[0]); ok && dm.DisplayFrequency > 1 {
+			// 0 and 1 mean "hardware default"
+			info.RefreshRate = int(dm.DisplayFrequency)
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+// Display orientations reported by GetDisplayOrientation.
+const (
+	OrientationLandscape        = 0
+	OrientationPortrait         = 1
+	OrientationLandscapeFlipped = 2
+	OrientationPortraitFlipped  = 3
+)
+
+// GetDisplayOrientation returns the orientation of the display showing the
+// window (the primary display before it exists). Landscape and portrait
+// follow the current resolution, so devices whose panel is natively portrait
+// report correctly too; flipped means rotated by 180 degrees from the matching
+// unflipped orientation. Returns OrientationLandscape if unknown.
+func GetDisplayOrientation() int {
+	hMon := monitorFromPoint(0, 0, monitorDefaultToPrimary)
+	if h := getHWND(); h != 0 && procMonitorFromWindow.Find() == nil {
+		hMon, _, _ = procMonitorFromWindow.Call(h, monitorDefaultNearest)
+	}
+	mi, ok := getMonitorInfoEx(hMon)
+	if !ok {
+		return OrientationLandscape
+	}
+	dm, ok := displaySettings(&mi.Device[0])
+	if !ok {
+		return OrientationLandscape
+	}
+	o := OrientationLandscape
+	if dm.PelsHeight > dm.PelsWidth {
+		o = OrientationPortrait
+	}
+	if dm.DisplayOrientation >= dmdo180 { // DMDO_180 or DMDO_270
+		o += 2
+	}
+	return o
+}
+
+// monitorFromPoint returns the HMONITOR containing the screen point x,y, or
+// the one given by flags (MONITOR_DEFAULTTO*) if none does.
+func monitorFromPoint(x, y int, flags uintptr) uintptr {
+	if procMonitorFromPoint.Find() != nil {
+		return 0
+	}
+	// POINT is passed by value, packed into one register
+	pt := uintptr(uint32(int32(x))) | uintptr(uint32(int32(y)))<<32
+	h, _, _ := procMonitorFromPoint.Call(pt, flags)
+	return h
+}
+
+// monitorIndex returns the index of hMon in enumeration order, or -1.
+func monitorIndex(hMon uintptr) int {
+	for i, m := range enumMonitors() {
+		if m == hMon {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetMonitorFromPoint returns the index of the monitor containing the screen
+// point x,y, or of the nearest monitor if the point is off-screen. Returns -1
+// if the monitor cannot be determined.
+func GetMonitorFromPoint(x, y int) int {
+	return monitorIndex(monitorFromPoint(x, y, monitorDefaultNearest))
+}
+
+// CreateWindowOnMonitor creates the main window centered in the work area of
+// the given monitor and waits until it is ready. width and height are the
+// client size in DIPs (96-DPI units) and are scaled by the monitor's DPI, so
+// the window has the same apparent size on every display. The position is
+// handed to the DLL before the window is first shown, so it does not flash on
+// the primary monitor first (older DLLs move it right after creation). An
+// invalid index falls back to the primary monitor.
+func CreateWindowOnMonitor(index, width, height int, title string) (Handle, error) {
+	hMon := monitorHandle(index)
+	if hMon == 0 {
+		logf(LogWarn, "CreateWindowOnMonitor: no monitor %d, using the primary monitor", index)
+		hMon = monitorFromPoint(0, 0, monitorDefaultToPrimary)
+	}
+	scale := monitorScale(hMon)
+	pw := int(math.Round(float64(width) * scale))
+	ph := int(math.Round(float64(height) * scale))
+	mi, ok := getMonitorInfo(hMon)
+	if ok && pSetInitialWindowPosition != nil {
+		// client size for now; recentered below once the frame is known
+		x, y := centerIn(mi.RcWork, pw, ph)
+		pSetInitialWindowPosition.Call(uintptr(int32(x)), uintptr(int32(y)))
+	}
+	h, err := CreateWindowAndWait(pw, ph, title, 5*time.Second)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		ow, oh := GetWindowOuterSize()
+		SetWindowPosition(centerIn(mi.RcWork, ow, oh))
+	}
+	return h, nil
+}
+
+// centerIn returns the top-left corner that centers a w x h box in rc.
+func centerIn(rc rect, w, h int) (x, y int) {
+	return int(rc.Left) + (int(rc.Right-rc.Left)-w)/2, int(rc.Top) + (int(rc.Bottom-rc.Top)-h)/2
+}
+
+func rectToImage(rc rect) image.Rectangle {
+	return image.Rect(int(rc.Left), int(rc.Top), int(rc.Right), int(rc.Bottom))
+}
@@ -0,0 +1,28 @@
+package winui
+
+import "testing"
+
+func TestGetMonitorsHasPrimary(t *testing.T) {
+	mons := GetMonitors()
+	if len(mons) == 0 {
+		t.Skip("no display attached")
+	}
+	primaries := 0
+	for _, m := range mons {
+		if m.Primary {
+			primaries++
+		}
+		if m.Bounds.Empty() {
+			t.Errorf("monitor %d: empty bounds", m.Index)
+		}
+		if !m.WorkArea.In(m.Bounds) {
+			t.Errorf("monitor %d: work area %v outside bounds %v", m.Index, m.WorkArea, m.Bounds)
+		}
+		if m.Scale <= 0 {
+			t.Errorf("monitor %d: scale %v", m.Index, m.Scale)
+		}
+	}
+	if primaries != 1 {
+		t.Fatalf("%d primary monitors among %d, want 1", primaries, len(mons))
+	}
+}
@@ -0,0 +1,70 @@
+package winui
+
+import (
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Frame pacing sleeps. A plain Sleep is rounded up to the system timer
+// resolution (15.6 ms by default), which makes 120/144 FPS pacing jitter.
+// paceSleep waits on a high-resolution waitable timer (Windows 10 1803+) and
+// otherwise raises the timer resolution to 1 ms once and sleeps.
+
+var (
+	procCreateWaitableTimerExW = kernel32.NewProc("CreateWaitableTimerExW")
+	procSetWaitableTimer       = kernel32.NewProc("SetWaitableTimer")
+
+	winmm               = windows.NewLazySystemDLL("winmm.dll")
+	procTimeBeginPeriod = winmm.NewProc("timeBeginPeriod")
+)
+
+const (
+	createWaitableTimerHighResolution = 0x00000002 // CREATE_WAITABLE_TIMER_HIGH_RESOLUTION
+	timerAllAccess                    = 0x001F0003 // TIMER_ALL_ACCESS
+)
+
+var (
+	paceTimerOnce sync.Once
+	paceTimer     uintptr    // high-resolution timer; 0 if unsupported
+	paceTimerMu   sync.Mutex // one waiter per timer
+)
+
+// initPaceTimer creates the shared timer, or raises the timer resolution for
+// the Sleep fallback when high-resolution timers are unavailable.
+func initPaceTimer() {
+	if procCreateWaitableTimerExW.Find() == nil && procSetWaitableTimer.Find() == nil {
+		h, _, _ := procCreateWaitableTimerExW.Call(0, 0, createWaitableTimerHighResolution, timerAllAccess)
+		if h != 0 {
+			paceTimer = h
+			return
+		}
+	}
+	logf(LogDebug, "high-resolution timer unavailable; pacing with timeBeginPeriod(1)")
+	if procTimeBeginPeriod.Find() == nil {
+		// never reset: loops can be restarted at any time in the process
+		procTimeBeginPeriod.Call(1)
+	}
+}
+
+// paceSleep sleeps for d with sub-millisecond precision where the system
+// allows it. Concurrent callers beyond the first fall back to time.Sleep.
+func paceSleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	paceTimerOnce.Do(initPaceTimer)
+	if paceTimer == 0 || !paceTimerMu.TryLock() {
+		time.Sleep(d)
+		return
+	}
+	defer paceTimerMu.Unlock()
+	due := -int64(d / 100) // relative, in 100 ns units
+	if r, _, _ := procSetWaitableTimer.Call(paceTimer, uintptr(unsafe.Pointer(&due)), 0, 0, 0, 0); r == 0 {
+		time.Sleep(d)
+		return
+	}
+	windows.WaitForSingleObject(windows.Handle(paceTimer), windows.INFINITE)
+}
@@ -0,0 +1,91 @@
+package winui
+
+import "unsafe"
+
+// Restore ("normal") placement. GetWindowPlacement reports the rectangle the
+// window returns to when un-maximized or restored, whatever its current
+// state, which is what should be persisted across sessions.
+
+var (
+	procGetWindowPlacement = user32.NewProc("GetWindowPlacement")
+	procSetWindowPlacement = user32.NewProc("SetWindowPlacement")
+	procMonitorFromWindow  = user32.NewProc("MonitorFromWindow")
+)
+
+const (
+	monitorDefaultNearest   = 0x00000002 // MONITOR_DEFAULTTONEAREST
+	wpfAsyncWindowPlacement = 0x0004     // WPF_ASYNCWINDOWPLACEMENT
+)
+
+// windowPlacement mirrors the Win32 WINDOWPLACEMENT struct.
+type windowPlacement struct {
+	Length    uint32
+	Flags     uint32
+	ShowCmd   uint32
+	MinPos    point
+	MaxPos    point
+	NormalPos rect
+}
+
+// workspaceOffset returns what to add to WINDOWPLACEMENT coordinates to get
+// screen coordinates. They are relative to the work area of the window's
+// monitor (so a top or left taskbar shifts them), except for tool windows.
+func workspaceOffset(h uintptr) (dx, dy int) {
+	if procGetWindowLongPtrW.Find() == nil {
+		idxEx := int32(GWL_EXSTYLE)
+		if styleEx, _, _ := procGetWindowLongPtrW.Call(h, uintptr(idxEx)); styleEx&WS_EX_TOOLWINDOW != 0 {
+			return 0, 0
+		}
+	}
+	if procMonitorFromWindow.Find() != nil {
+		return 0, 0
+	}
+	hMon, _, _ := procMonitorFromWindow.Call(h, monitorDefaultNearest)
+	mi, ok := getMonitorInfo(hMon)
+	if !ok {
+		return 0, 0
+	}
+	return int(mi.RcWork.Left - mi.RcMonitor.Left), int(mi.RcWork.Top - mi.RcMonitor.Top)
+}
+
+func getPlacement(h uintptr) (windowPlacement, bool) {
+	var wp windowPlacement
+	if h == 0 || procGetWindowPlacement.Find() != nil {
+		return wp, false
+	}
+	wp.Length = uint32(unsafe.Sizeof(wp))
+	r, _, _ := procGetWindowPlacement.Call(h, uintptr(unsafe.Pointer(&wp)))
+	return wp, r != 0
+}
+
+// GetWindowNormalRect returns the outer rectangle (screen coordinates) the
+// window occupies when neither maximized nor minimized. Unlike
+// GetWindowPosition and GetWindowOuterSize it does not change while the
+// window is maximized, minimized or fullscreen, so it is the size to save on
+// exit. Returns zeros if the window is unavailable.
+func GetWindowNormalRect() (x, y, w, h int) {
+	hWnd := getHWND()
+	wp, ok := getPlacement(hWnd)
+	if !ok {
+		return 0, 0, 0, 0
+	}
+	dx, dy := workspaceOffset(hWnd)
+	rc := wp.NormalPos
+	return int(rc.Left) + dx, int(rc.Top) + dy, int(rc.Right - rc.Left), int(rc.Bottom - rc.Top)
+}
+
+// SetWindowNormalRect sets the restore rectangle (screen coordinates, outer
+// size) without changing the current state: a maximized window stays
+// maximized and returns to this rectangle when restored.
+func SetWindowNormalRect(x, y, w, h int) {
+	hWnd := getHWND()
+	wp, ok := getPlacement(hWnd)
+	if !ok || procSetWindowPlacement.Find() != nil {
+		return
+	}
+	dx, dy := workspaceOffset(hWnd)
+	wp.NormalPos = rect{int32(x - dx), int32(y - dy), int32(x - dx + w), int32(y - dy + h)}
+	// the window belongs to the native UI thread; do not block on it
+	wp.Flags |= wpfAsyncWindowPlacement
+	procSetWindowPlacement.Call(hWnd, uintptr(unsafe.Pointer(&wp)))
+}
@@ -0,0 +1,56 @@
+package winui
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// Raw mouse input. The native layer registers the mouse for WM_INPUT and
+// accumulates its relative motion; PollEvents moves it into the per-frame
+// state read by GetRawMouseDelta. Raw motion is in device units (mickeys),
+// unaffected by pointer speed, acceleration and the screen edges, which is
+// what camera and aiming controls want.
+
+// optional procs; nil when the DLL predates raw input support
+var pSetRawMouseInput, pGetRawMouseDelta *nativeProc
+
+var rawMouseOn atomic.Bool
+
+// EnableRawMouseInput turns raw mouse input on or off. While on, each frame's
+// relative mouse motion is available from GetRawMouseDelta; it is only
+// received while the window is in the foreground. The regular cursor position
+// and button state keep working. May be called before the window exists.
+func EnableRawMouseInput(on bool) {
+	if pSetRawMouseInput == nil {
+		return
+	}
+	rawMouseOn.Store(on)
+	pSetRawMouseInput.Call(boolArg(on))
+}
+
+// GetRawMouseDelta returns the raw mouse motion of the current frame, in
+// device units (positive is right and down). Returns zeros while raw input
+// is disabled.
+func GetRawMouseDelta() (dx, dy int) {
+	mouseStateMu.Lock()
+	dx, dy = mouseRawDX, mouseRawDY
+	mouseStateMu.Unlock()
+	return dx, dy
+}
+
+// pullRawMouseDelta adds the motion accumulated natively since the last poll
+// to this frame's delta.
+func pullRawMouseDelta() {
+	if pGetRawMouseDelta == nil || !rawMouseOn.Load() {
+		return
+	}
+	var dx, dy int32
+	pGetRawMouseDelta.Call(uintptr(unsafe.Pointer(&dx)), uintptr(unsafe.Pointer(&dy)))
+	if dx == 0 && dy == 0 {
+		return
+	}
+	mouseStateMu.Lock()
+	mouseRawDX += int(dx)
+	mouseRawDY += int(dy)
+	mouseStateMu.Unlock()
+}
@@ -0,0 +1,167 @@
+package winui
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Input recording and playback. Recording taps the native input callback;
+// playback feeds events back through handleNativeInput, the same path live
+// input takes, so every input helper observes them exactly as recorded.
+// Timing is frame based: an event recorded during frame N of a recording is
+// replayed so that it is visible in frame N after playback starts. A frame
+// ends at each ResetKeyTransitions call.
+
+// frameCounter counts ResetKeyTransitions calls (completed frames).
+var frameCounter uint64
+
+// RecordedInput is one captured native input event.
+type RecordedInput struct {
+	Frame        int           `json:"frame"` // frames since recording start
+	Time         time.Duration `json:"t"`     // offset since recording start
+	Kind         int           `json:"kind"`
+	CodeWithMods int           `json:"code"` // raw: low 16 bits code, high 16 bits mods
+	Action       int           `json:"action"`
+	PackedXY     uint64        `json:"xy"` // raw: low 32 bits x, high 32 bits y
+}
+
+// InputRecording holds captured input. Create one with StartInputRecording or
+// by unmarshalling a saved recording.
+type InputRecording struct {
+	mu         sync.Mutex
+	events     []RecordedInput
+	start      time.Time
+	startFrame uint64
+}
+
+var activeRecording atomic.Pointer[InputRecording]
+
+// StartInputRecording begins capturing every native input event into a new
+// recording, replacing any recording in progress. Call Stop to finish.
+func StartInputRecording() *InputRecording {
+	rec := &InputRecording{start: time.Now(), startFrame: atomic.LoadUint64(&frameCounter)}
+	activeRecording.Store(rec)
+	return rec
+}
+
+// Stop ends the recording if it is the active one. It is safe to call twice.
+func (r *InputRecording) Stop() {
+	activeRecording.CompareAndSwap(r, nil)
+}
+
+// Events returns a copy of the captured events.
+func (r *InputRecording) Events() []RecordedInput {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedInput(nil), r.events...)
+}
+
+// Len returns the number of captured events.
+func (r *InputRecording) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+// recordInput appends a live native event to the active recording, if any.
+func recordInput(kind, codeWithMods, action int, packedXY uint64) {
+	rec := activeRecording.Load()
+	if rec == nil {
+		return
+	}
+	ev := RecordedInput{
+		Frame:        int(atomic.LoadUint64(&frameCounter) - rec.startFrame),
+		Time:         time.Since(rec.start),
+		Kind:         kind,
+		CodeWithMods: codeWithMods,
+		Action:       action,
+		PackedXY:     packedXY,
+	}
+	rec.mu.Lock()
+	rec.events = append(rec.events, ev)
+	rec.mu.Unlock()
+}
+
+// recordingFileVersion is bumped when the JSON layout changes.
+const recordingFileVersion = 1
+
+type recordingFile struct {
+	Version int             `json:"version"`
+	Events  []RecordedInput `json:"events"`
+}
+
+// MarshalJSON encodes the recording for saving to disk.
+func (r *InputRecording) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recordingFile{Version: recordingFileVersion, Events: r.Events()})
+}
+
+// UnmarshalJSON decodes a recording produced by MarshalJSON.
+func (r *InputRecording) UnmarshalJSON(data []byte) error {
+	var f recordingFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	if f.Version != recordingFileVersion {
+		return errors.New("winui: unsupported input recording version")
+	}
+	r.mu.Lock()
+	r.events = f.Events
+	r.mu.Unlock()
+	return nil
+}
+
+// playback state; guarded by playbackMu
+var (
+	playbackMu     sync.Mutex
+	playbackEvents []RecordedInput
+	playbackPos    int
+	playbackStart  uint64
+)
+
+// PlayInputRecording replays rec into the input state, starting with the
+// current frame. Events of later frames are injected as frames complete
+// (ResetKeyTransitions), so the loop must keep running for playback to
+// progress. Live input keeps working during playback. Starting a new
+// playback replaces the current one; pass nil to stop.
+func PlayInputRecording(rec *InputRecording) {
+	var evs []RecordedInput
+	if rec != nil {
+		evs = rec.Events()
+	}
+	playbackMu.Lock()
+	playbackEvents = evs
+	playbackPos = 0
+	playbackStart = atomic.LoadUint64(&frameCounter)
+	playbackMu.Unlock()
+	advancePlayback()
+}
+
+// IsInputPlaybackActive reports whether recorded events are still pending.
+func IsInputPlaybackActive() bool {
+	playbackMu.Lock()
+	defer playbackMu.Unlock()
+	return playbackPos < len(playbackEvents)
+}
+
+// advancePlayback injects the recorded events due in the current frame.
+// Events are collected under the lock and injected after it is released.
+func advancePlayback() {
+	playbackMu.Lock()
+	if playbackPos >= len(playbackEvents) {
+		playbackMu.Unlock()
+		return
+	}
+	frame := int(atomic.LoadUint64(&frameCounter) - playbackStart)
+	var due []RecordedInput
+	for playbackPos < len(playbackEvents) && playbackEvents[playbackPos].Frame <= frame {
+		due = append(due, playbackEvents[playbackPos])
+		playbackPos++
+	}
+	playbackMu.Unlock()
+	for _, ev := range due {
+		handleNativeInput(ev.Kind, ev.CodeWithMods, ev.Action, ev.PackedXY)
+	}
+}
@@ -0,0 +1,94 @@
+package winui
+
+import (
+	"math"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Window regions (SetWindowRgn) clip the window to a non-rectangular shape:
+// nothing outside the region is drawn or receives input. Unlike DWM corner
+// rounding the shape is arbitrary, but its edges are not antialiased.
+
+var (
+	gdi32 = windows.NewLazySystemDLL("gdi32.dll")
+
+	procCreateRoundRectRgn = gdi32.NewProc("CreateRoundRectRgn")
+	procCreatePolygonRgn   = gdi32.NewProc("CreatePolygonRgn")
+	procDeleteObject       = gdi32.NewProc("DeleteObject")
+	procSetWindowRgn       = user32.NewProc("SetWindowRgn")
+)
+
+const polyFillWinding = 2 // WINDING
+
+// WindowRegion is a window shape for SetWindowRegion in DIPs, relative to the
+// top-left corner of the window (including any frame). Build one with
+// RoundRectRegion or PolygonRegion.
+type WindowRegion struct {
+	x, y, w, h, radius float64
+	poly               [][2]float64 // nil for a rounded rectangle
+}
+
+// RoundRectRegion returns a rectangle with corners rounded by radius; 0 gives
+// square corners.
+func RoundRectRegion(x, y, w, h, radius float64) WindowRegion {
+	return WindowRegion{x: x, y: y, w: w, h: h, radius: radius}
+}
+
+// PolygonRegion returns the polygon through points (x, y pairs, implicitly
+// closed). Self-intersecting outlines are filled with the nonzero winding rule.
+func PolygonRegion(points ...[2]float64) WindowRegion {
+	return WindowRegion{poly: append([][2]float64(nil), points...)}
+}
+
+// create builds the GDI region scaled to physical pixels; 0 on failure.
+func (r WindowRegion) create(scale float64) uintptr {
+	px := func(v float64) uintptr { return uintptr(int32(math.Round(v * scale))) }
+	if r.poly == nil {
+		d := px(2 * r.radius) // ellipse size of the corners
+		hrgn, _, _ := procCreateRoundRectRgn.Call(px(r.x), px(r.y), px(r.x+r.w), px(r.y+r.h), d, d)
+		return hrgn
+	}
+	if len(r.poly) < 3 {
+		return 0
+	}
+	pts := make([]point, len(r.poly))
+	for i, p := range r.poly {
+		pts[i] = point{int32(math.Round(p[0] * scale)), int32(math.Round(p[1] * scale))}
+	}
+	hrgn, _, _ := procCreatePolygonRgn.Call(uintptr(unsafe.Pointer(&pts[0])), uintptr(len(pts)), polyFillWinding)
+	return hrgn
+}
+
+// SetWindowRegion clips the window to r, scaled by the current DPI. Use it
+// with a borderless window, since the frame is not reshaped with it. The
+// region does not follow later DPI or size changes; call it again from
+// OnResize if needed. Polygons with fewer than three points are ignored.
+func SetWindowRegion(r WindowRegion) {
+	h := getHWND()
+	if h == 0 || procSetWindowRgn.Find() != nil || procCreateRoundRectRgn.Find() != nil || procCreatePolygonRgn.Find() != nil {
+		return
+	}
+	scale, _ := GetWindowScaleDPI()
+	hrgn := r.create(scale)
+	if hrgn == 0 {
+		logf(LogWarn, "SetWindowRegion: could not create the region")
+		return
+	}
+	if ok, _, _ := procSetWindowRgn.Call(h, hrgn, 1); ok == 0 {
+		// the system only takes ownership on success
+		procDeleteObject.Call(hrgn)
+		logf(LogWarn, "SetWindowRegion: SetWindowRgn failed")
+	}
+}
+
+// ClearWindowRegion removes the region set by SetWindowRegion, restoring the
+// rectangular window.
+func ClearWindowRegion() {
+	h := getHWND()
+	if h == 0 || procSetWindowRgn.Find() != nil {
+		return
+	}
+	procSetWindowRgn.Call(h, 0, 1)
+}
@@ -0,0 +1,119 @@
+package winui
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// Key combinations and shortcuts. Modifier masks use the Mod* constants; the
+// aggregate masks (ModShift, ModControl, ...) accept either side while a
+// side-specific bit (ModLShift, ...) requires that side. Groups that are not
+// requested must be up, so Ctrl+S does not fire for Ctrl+Shift+S.
+// -----------------------------------------------------------------------------
+
+var modGroups = [...]int{ModShift, ModControl, ModAlt, ModWin}
+
+// modsMatch reports whether the observed modifier mask satisfies want.
+func modsMatch(want, have int) bool {
+	for _, g := range modGroups {
+		req, cur := want&g, have&g
+		switch {
+		case req == 0:
+			if cur != 0 {
+				return false
+			}
+		case req == g:
+			if cur == 0 {
+				return false
+			}
+		default:
+			if cur&req == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsKeyComboPressed returns true if key was pressed this frame while exactly
+// the modifiers in mods were held.
+func IsKeyComboPressed(mods, key int) bool {
+	return IsKeyPressed(key) && modsMatch(mods, GetModifiers())
+}
+
+// IsKeyComboReleased returns true if key was released this frame while exactly
+// the modifiers in mods were held.
+func IsKeyComboReleased(mods, key int) bool {
+	return IsKeyReleased(key) && modsMatch(mods, GetModifiers())
+}
+
+type shortcut struct {
+	name string
+	mods int
+	key  int
+	fn   func()
+}
+
+// ShortcutManager evaluates a set of named keyboard shortcuts once per frame.
+// Call Update from OnUpdate (or any loop after event polling). Shortcuts fire
+// on press; when several registered shortcuts match the same key in a frame
+// only the most specific one (most modifier bits, then first added) fires.
+type ShortcutManager struct {
+	mu    sync.Mutex
+	items []shortcut
+}
+
+// NewShortcutManager returns an empty ShortcutManager.
+func NewShortcutManager() *ShortcutManager { return &ShortcutManager{} }
+
+// Add registers (or replaces) the shortcut name for mods+key.
+func (sm *ShortcutManager) Add(name string, mods, key int, fn func()) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	for i := range sm.items {
+		if sm.items[i].name == name {
+			sm.items[i] = shortcut{name, mods, key, fn}
+			return
+		}
+	}
+	sm.items = append(sm.items, shortcut{name, mods, key, fn})
+}
+
+// Remove unregisters the shortcut name, if present.
+func (sm *ShortcutManager) Remove(name string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	for i := range sm.items {
+		if sm.items[i].name == name {
+			sm.items = append(sm.items[:i], sm.items[i+1:]...)
+			return
+		}
+	}
+}
+
+// Update fires the callbacks of shortcuts pressed this frame. Callbacks run
+// after the manager lock is released and may call Add/Remove.
+func (sm *ShortcutManager) Update() {
+	mods := GetModifiers()
+	sm.mu.Lock()
+	best := make(map[int]shortcut)
+	for _, s := range sm.items {
+		if s.fn == nil || !IsKeyPressed(s.key) || !modsMatch(s.mods, mods) {
+			continue
+		}
+		if cur, ok := best[s.key]; !ok || bits.OnesCount(uint(s.mods)) > bits.OnesCount(uint(cur.mods)) {
+			best[s.key] = s
+		}
+	}
+	var fire []func()
+	for _, s := range sm.items {
+		if b, ok := best[s.key]; ok && b.name == s.name {
+			fire = append(fire, s.fn)
+		}
+	}
+	sm.mu.Unlock()
+	for _, fn := range fire {
+		fn()
+	}
+}
@@ -0,0 +1,105 @@
+package winui
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// InputSnapshot is an immutable copy of the keyboard and mouse state taken by
+// SnapshotInput. Its methods mirror the global input helpers but read only the
+// copy, so any number of goroutines can query it without locking and all
+// queries agree with each other.
+type InputSnapshot struct {
+	frame uint64
+
+	keysDown     map[int]bool
+	keysPressed  map[int]bool
+	keysReleased map[int]bool
+	keysRepeat   map[int]bool
+	mods         int
+
+	mouseDown     map[int]bool
+	mousePressed  map[int]bool
+	mouseReleased map[int]bool
+	mouseX        int
+	mouseY        int
+	wheelDelta    int
+}
+
+// SnapshotInput captures the current input state under the input locks, so
+// the result is consistent across keys, mouse buttons and modifiers. The
+// pressed/released/repeat sets are those of the current frame (until the next
+// ResetKeyTransitions). The key and char queues are not part of a snapshot;
+// drain them with GetKeyPressed and GetCharPressed.
+func SnapshotInput() InputSnapshot {
+	s := InputSnapshot{frame: atomic.LoadUint64(&frameCounter)}
+	// lock order consistent with the input callback: mouse then key
+	mouseStateMu.Lock()
+	s.mouseDown = copyKeySet(mouseDown)
+	s.mousePressed = copyKeySet(mousePressedOnce)
+	s.mouseReleased = copyKeySet(mouseReleasedOnce)
+	s.mouseX, s.mouseY = mouseX, mouseY
+	s.wheelDelta = mouseWheelDelta
+	keyStateMu.Lock()
+	s.keysDown = copyKeySet(keyDown)
+	s.keysPressed = copyKeySet(keyPressedOnce)
+	s.keysReleased = copyKeySet(keyReleasedOnce)
+	s.keysRepeat = copyKeySet(keyRepeat)
+	s.mods = currentMods
+	keyStateMu.Unlock()
+	mouseStateMu.Unlock()
+	return s
+}
+
+func copyKeySet(m map[int]bool) map[int]bool {
+	out := make(map[int]bool, len(m))
+	for k, v := range m {
+		if v {
+			out[k] = true
+		}
+	}
+	return out
+}
+
+// Frame returns the frame number (count of completed frames) the snapshot was taken in.
+func (s InputSnapshot) Frame() uint64 { return s.frame }
+
+// IsKeyDown reports whether key was held.
+func (s InputSnapshot) IsKeyDown(key int) bool { return s.keysDown[key] }
+
+// IsKeyPressed reports whether key went down during the frame.
+func (s InputSnapshot) IsKeyPressed(key int) bool { return s.keysPressed[key] }
+
+// IsKeyPressedRepeat reports whether key auto-repeated during the frame.
+func (s InputSnapshot) IsKeyPressedRepeat(key int) bool { return s.keysRepeat[key] }
+
+// IsKeyReleased reports whether key went up during the frame.
+func (s InputSnapshot) IsKeyReleased(key int) bool { return s.keysReleased[key] }
+
+// KeysDown returns the held keys in ascending order.
+func (s InputSnapshot) KeysDown() []int {
+	out := make([]int, 0, len(s.keysDown))
+	for k := range s.keysDown {
+		out = append(out, k)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// Modifiers returns the modifiers mask (Mod* constants).
+func (s InputSnapshot) Modifiers() int { return s.mods }
+
+// IsMouseButtonDown reports whether button was held.
+func (s InputSnapshot) IsMouseButtonDown(button int) bool { return s.mouseDown[button] }
+
+// IsMouseButtonPressed reports whether button went down during the frame.
+func (s InputSnapshot) IsMouseButtonPressed(button int) bool { return s.mousePressed[button] }
+
+// IsMouseButtonReleased reports whether button went up during the frame.
+func (s InputSnapshot) IsMouseButtonReleased(button int) bool { return s.mouseReleased[button] }
+
+// MousePosition returns the mouse position in client-area physical pixels.
+func (s InputSnapshot) MousePosition() (int, int) { return s.mouseX, s.mouseY }
+
+// MouseWheelMove returns the wheel movement of the frame in notches (see GetMouseWheelMove).
+func (s InputSnapshot) MouseWheelMove() float64 { return float64(s.wheelDelta) / wheelDelta }
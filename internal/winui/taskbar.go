@@ -0,0 +1,40 @@
+package winui
+
+import "math"
+
+// Taskbar button progress, backed by ITaskbarList3 in the native layer.
+
+// optional procs; nil when the DLL predates taskbar progress
+var pSetTaskbarProgress, pSetTaskbarProgressState *nativeProc
+
+// Taskbar progress states for SetTaskbarProgressState (TBPFLAG values).
+const (
+	TBStateNoProgress    = 0x0 // hide the progress bar
+	TBStateIndeterminate = 0x1 // marquee, no value
+	TBStateNormal        = 0x2 // green
+	TBStateError         = 0x4 // red
+	TBStatePaused        = 0x8 // yellow
+)
+
+// taskbarProgressScale is the resolution passed to the native side.
+const taskbarProgressScale = 10000
+
+// SetTaskbarProgress shows value out of max in the window's taskbar button.
+// A hidden or indeterminate bar switches to TBStateNormal; error and paused
+// keep their color. value is clamped to 0..max; max <= 0 is ignored.
+func SetTaskbarProgress(value, max float64) {
+	if pSetTaskbarProgress == nil || max <= 0 {
+		return
+	}
+	p := math.Min(math.Max(value/max, 0), 1)
+	pSetTaskbarProgress.Call(uintptr(math.Round(p*taskbarProgressScale)), uintptr(taskbarProgressScale))
+}
+
+// SetTaskbarProgressState sets the taskbar progress state (TBStateNoProgress,
+// TBStateIndeterminate, TBStateNormal, TBStateError, TBStatePaused).
+func SetTaskbarProgressState(state int) {
+	if pSetTaskbarProgressState == nil {
+		return
+	}
+	pSetTaskbarProgressState.Call(uintptr(int32(state)))
+}
@@ -0,0 +1,101 @@
+package winui
+
+import "unicode"
+
+// TextBuffer is an editable string with a cursor for custom-drawn text
+// fields. It works on runes, so the cursor never splits a multi-byte
+// character. The zero value is an empty buffer ready to use. A TextBuffer is
+// not safe for concurrent use; feed and read it from the loop goroutine.
+type TextBuffer struct {
+	runes  []rune
+	cursor int // insertion point, 0..len(runes)
+}
+
+// Navigation and editing keys handled by Feed.
+const (
+	vkBack   = 0x08
+	vkEnd    = 0x23
+	vkHome   = 0x24
+	vkLeft   = 0x25
+	vkRight  = 0x27
+	vkDelete = 0x2E
+)
+
+// String returns the buffer contents.
+func (b *TextBuffer) String() string { return string(b.runes) }
+
+// Len returns the length in runes.
+func (b *TextBuffer) Len() int { return len(b.runes) }
+
+// Cursor returns the cursor position in runes from the start.
+func (b *TextBuffer) Cursor() int { return b.cursor }
+
+// SetString replaces the contents and moves the cursor to the end.
+func (b *TextBuffer) SetString(s string) {
+	b.runes = []rune(s)
+	b.cursor = len(b.runes)
+}
+
+// Insert inserts r at the cursor and advances the cursor past it.
+func (b *TextBuffer) Insert(r rune) {
+	b.runes = append(b.runes, 0)
+	copy(b.runes[b.cursor+1:], b.runes[b.cursor:])
+	b.runes[b.cursor] = r
+	b.cursor++
+}
+
+// Backspace removes the rune before the cursor, if any.
+func (b *TextBuffer) Backspace() {
+	if b.cursor == 0 {
+		return
+	}
+	b.runes = append(b.runes[:b.cursor-1], b.runes[b.cursor:]...)
+	b.cursor--
+}
+
+// Delete removes the rune after the cursor, if any.
+func (b *TextBuffer) Delete() {
+	if b.cursor == len(b.runes) {
+		return
+	}
+	b.runes = append(b.runes[:b.cursor], b.runes[b.cursor+1:]...)
+}
+
+// MoveCursor moves the cursor by delta runes, clamped to the buffer bounds.
+func (b *TextBuffer) MoveCursor(delta int) {
+	b.cursor = min(max(b.cursor+delta, 0), len(b.runes))
+}
+
+// Feed applies one frame of keyboard input: it drains the character queue
+// (GetCharPressed), inserting printable characters, and handles Backspace,
+// Delete, Left, Right, Home and End presses including auto-repeat. Call it
+// once per frame from OnUpdate while the field has focus; other consumers of
+// GetCharPressed will not see the drained characters. Within one frame the
+// edit keys are applied after the typed characters.
+func (b *TextBuffer) Feed(win *Window) {
+	for c := win.GetCharPressed(); c != 0; c = win.GetCharPressed() {
+		// control characters (Enter, Tab, Ctrl+letter, ...) are not text
+		if r := rune(c); unicode.IsPrint(r) {
+			b.Insert(r)
+		}
+	}
+	hit := func(key int) bool { return win.IsKeyPressed(key) || win.IsKeyPressedRepeat(key) }
+	if hit(vkBack) {
+		b.Backspace()
+	}
+	if hit(vkDelete) {
+		b.Delete()
+	}
+	if hit(vkLeft) {
+		b.MoveCursor(-1)
+	}
+	if hit(vkRight) {
+		b.MoveCursor(1)
+	}
+	if hit(vkHome) {
+		b.cursor = 0
+	}
+	if hit(vkEnd) {
+		b.cursor = len(b.runes)
+	}
+}
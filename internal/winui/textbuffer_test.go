@@ -0,0 +1,69 @@
+package winui
+
+import "testing"
+
+func checkBuffer(t *testing.T, b *TextBuffer, s string, cursor int) {
+	t.Helper()
+	if b.String() != s || b.Cursor() != cursor {
+		t.Fatalf("buffer = %q cursor %d, want %q cursor %d", b.String(), b.Cursor(), s, cursor)
+	}
+}
+
+func TestTextBufferMultiByteRunes(t *testing.T) {
+	var b TextBuffer
+	b.Insert('a') // 1 byte
+	b.Insert('é') // 2 bytes
+	b.Insert('€') // 3 bytes
+	b.Insert('😀') // 4 bytes
+	checkBuffer(t, &b, "aé€😀", 4)
+	if b.Len() != 4 {
+		t.Fatalf("Len = %d, want 4", b.Len())
+	}
+
+	b.Backspace()
+	checkBuffer(t, &b, "aé€", 3)
+
+	b.MoveCursor(-2)
+	b.Insert('😀')
+	checkBuffer(t, &b, "a😀é€", 2)
+
+	b.Delete()
+	checkBuffer(t, &b, "a😀€", 2)
+
+	b.Backspace()
+	checkBuffer(t, &b, "a€", 1)
+
+	b.Delete()
+	checkBuffer(t, &b, "a", 1)
+}
+
+func TestTextBufferEdges(t *testing.T) {
+	var b TextBuffer
+	b.Backspace()
+	b.Delete()
+	checkBuffer(t, &b, "", 0)
+
+	b.SetString("日本")
+	checkBuffer(t, &b, "日本", 2)
+	b.Delete() // at the end
+	checkBuffer(t, &b, "日本", 2)
+
+	b.MoveCursor(-2)
+	b.Backspace() // at the start
+	checkBuffer(t, &b, "日本", 0)
+}
+
+func TestTextBufferMoveCursorClamps(t *testing.T) {
+	var b TextBuffer
+	b.SetString("x€😀")
+
+	b.MoveCursor(-100)
+	checkBuffer(t, &b, "x€😀", 0)
+	b.MoveCursor(-1)
+	checkBuffer(t, &b, "x€😀", 0)
+
+	b.MoveCursor(100)
+	checkBuffer(t, &b, "x€😀", b.Len())
+	b.MoveCursor(1)
+	checkBuffer(t, &b, "x€😀", 3)
+}
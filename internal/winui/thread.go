@@ -0,0 +1,147 @@
+package winui
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// Thread management. COM apartments, window ownership and thread-local input
+// state (AttachThreadInput, the clipboard) belong to an OS thread, while Go
+// moves goroutines between threads freely. A goroutine that touches them must
+// therefore stay pinned with runtime.LockOSThread, and a thread that uses
+// COM must join an apartment first; WinUI objects need a single-threaded one
+// (STA). MainLoop sets both up for the goroutine that drives the window.
+
+// sFalse is the S_FALSE HRESULT CoInitializeEx returns when the thread is
+// already in the requested apartment.
+const sFalse = syscall.Errno(1)
+
+// initCOM joins the calling (locked) thread to a single-threaded apartment
+// and returns the matching cleanup. A thread already in the STA is fine; a
+// thread in the multithreaded apartment cannot switch and reports an error,
+// with a no-op cleanup.
+func initCOM() (func(), error) {
+	err := windows.CoInitializeEx(0, windows.COINIT_APARTMENTTHREADED)
+	switch {
+	case err == nil, errors.Is(err, sFalse):
+		// every successful call, S_FALSE included, needs its CoUninitialize
+		return windows.CoUninitialize, nil
+	case errors.Is(err, syscall.Errno(windows.RPC_E_CHANGED_MODE)):
+		return func() {}, errors.New("winui: thread is already in the multithreaded COM apartment")
+	default:
+		return func() {}, err
+	}
+}
+
+// UI thread state: the OS thread running MainLoop and the calls queued for it
+// by RunOnUIThread. Guarded by uiMu.
+var (
+	uiMu       sync.Mutex
+	uiThreadID uint32 // 0 when no MainLoop is running
+	uiCalls    []func()
+)
+
+// MainLoop runs a complete application on the calling goroutine: it locks the
+// OS thread, initializes COM as STA, creates the window wrapper, runs setup
+// (register callbacks and set properties there) and then drives the window
+// with Run until it is closed. Call it from main; it returns after OnDestroy.
+// If COM was already initialized on the thread in another apartment the
+// failure is logged and the loop runs anyway, since the XAML tree itself lives
+// on the native UI thread.
+func MainLoop(setup func(*Window)) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	uninit, err := initCOM()
+	if err != nil {
+		logf(LogWarn, "MainLoop: CoInitializeEx: %v", err)
+	}
+	defer uninit()
+
+	uiMu.Lock()
+	uiThreadID = windows.GetCurrentThreadId()
+	uiMu.Unlock()
+	defer func() {
+		uiMu.Lock()
+		uiThreadID = 0
+		pending := uiCalls
+		uiCalls = nil
+		uiMu.Unlock()
+		// still on the loop thread; release callers queued during shutdown
+		for _, fn := range pending {
+			fn()
+		}
+	}()
+
+	w := InitWindowHandler()
+	if setup != nil {
+		setup(w)
+	}
+	w.Run(context.Background())
+}
+
+// RunOnUIThread runs fn on the thread that drives the window and waits for it
+// to return. While MainLoop is running, fn is queued and runs at the start of
+// the next frame (or immediately when called from the loop itself). Without
+// MainLoop, fn runs on a fresh locked OS thread with COM initialized as STA.
+// A panic in fn is re-raised in the caller.
+func RunOnUIThread(fn func()) {
+	if fn == nil {
+		return
+	}
+	var panicked any
+	call := func() {
+		defer func() { panicked = recover() }()
+		fn()
+	}
+
+	done := make(chan struct{})
+	uiMu.Lock()
+	switch uiThreadID {
+	case 0:
+		uiMu.Unlock()
+		go func() {
+			defer close(done)
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+			uninit, err := initCOM()
+			if err != nil {
+				logf(LogWarn, "RunOnUIThread: CoInitializeEx: %v", err)
+			}
+			defer uninit()
+			call()
+		}()
+	case windows.GetCurrentThreadId():
+		// the loop goroutine is pinned, so a matching thread id means we are it
+		uiMu.Unlock()
+		call()
+		close(done)
+	default:
+		uiCalls = append(uiCalls, func() { call(); close(done) })
+		uiMu.Unlock()
+	}
+	<-done
+	if panicked != nil {
+		panic(panicked)
+	}
+}
+
+// runUICalls executes the RunOnUIThread calls queued for this thread. It is a
+// no-op on any thread other than MainLoop's.
+func runUICalls() {
+	uiMu.Lock()
+	if uiThreadID == 0 || uiThreadID != windows.GetCurrentThreadId() {
+		uiMu.Unlock()
+		return
+	}
+	pending := uiCalls
+	uiCalls = nil
+	uiMu.Unlock()
+	for _, fn := range pending {
+		fn()
+	}
+}
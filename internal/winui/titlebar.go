@@ -0,0 +1,44 @@
+package winui
+
+// Custom title bar (client-drawn chrome). The system caption buttons remain;
+// everything else in the title bar area belongs to the content, and a
+// rectangle of the content can be declared to behave like the caption.
+
+// optional procs; nil when the DLL predates custom title bar support
+var pSetCustomTitleBar, pSetTitleBarDragRegion, pGetTitleBarHeight *nativeProc
+
+// SetCustomTitleBar extends the window content into the title bar area when on
+// is true, hiding the default caption (title text and icon). Pair it with
+// SetTitleBarDragRegion so the window can still be moved. May be called before
+// the window exists; the setting is applied on creation.
+func SetCustomTitleBar(on bool) {
+	if pSetCustomTitleBar == nil {
+		return
+	}
+	pSetCustomTitleBar.Call(boolArg(on))
+}
+
+// SetTitleBarDragRegion marks the client rectangle x,y,w,h (physical pixels)
+// as the caption: dragging it moves the window and double-clicking maximizes.
+// A zero width or height clears the region. Only effective while
+// SetCustomTitleBar(true) is active; update it when the layout changes size.
+func SetTitleBarDragRegion(x, y, w, h int) {
+	if pSetTitleBarDragRegion == nil {
+		return
+	}
+	pSetTitleBarDragRegion.Call(uintptr(int32(x)), uintptr(int32(y)), uintptr(int32(w)), uintptr(int32(h)))
+}
+
+// GetWindowContentRect returns the part of the client area below the custom
+// title bar, in client pixels: the client rect minus the taller of the system
+// caption and the drag region set with SetTitleBarDragRegion. Without a custom
+// title bar it is the whole client rect.
+func GetWindowContentRect() (x, y, w, h int) {
+	w, h = GetWindowClientSize()
+	if pGetTitleBarHeight == nil {
+		return 0, 0, w, h
+	}
+	top, _, _ := pGetTitleBarHeight.Call()
+	y = min(int(int32(top)), h)
+	return 0, y, w, h - y
+}
@@ -0,0 +1,18 @@
+package winui
+
+// Notification area (system tray) behaviour. The icon is owned by the native
+// layer, which receives its messages through the main window subclass.
+
+// optional proc; nil when the DLL predates tray support
+var pSetMinimizeToTray *nativeProc
+
+// SetMinimizeToTray makes minimizing hide the window behind a notification
+// area icon (using the window icon and title) instead of the taskbar. Clicking
+// the icon restores the window. Turning it off while the window is hidden
+// restores it; the icon is removed when the window closes.
+func SetMinimizeToTray(on bool) {
+	if pSetMinimizeToTray == nil {
+		return
+	}
+	pSetMinimizeToTray.Call(boolArg(on))
+}
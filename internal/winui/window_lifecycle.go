@@ -2,22 +2,104 @@ package winui
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sort"
 	"sync"
 	"time"
 )
 
 // WindowContext is a simple per-window key-value store.
 type WindowContext struct {
-	mu sync.RWMutex
-	m  map[string]any
+	mu        sync.RWMutex
+	m         map[string]any
+	observers map[string][]func(old, new any)
 }
 
+// ContextWildcard registers an OnChange observer for every key.
+const ContextWildcard = "*"
+
 func NewWindowContext() *WindowContext { return &WindowContext{m: make(map[string]any)} }
 
+// Set stores value under key and notifies OnChange observers when the key is
+// new or the value differs from the previous one. Observers run after the
+// lock is released so they may safely call back into the context.
 func (wc *WindowContext) Set(key string, value any) {
 	wc.mu.Lock()
+	old, existed := wc.m[key]
 	wc.m[key] = value
+	var obs []func(old, new any)
+	if !existed || !sameValue(old, value) {
+		obs = append(obs, wc.observers[key]...)
+		obs = append(obs, wc.observers[ContextWildcard]...)
+	}
+	wc.mu.Unlock()
+	for _, fn := range obs {
+		fn(old, value)
+	}
+}
+
+// OnChange registers fn to be called whenever the value stored under key
+// changes. Use ContextWildcard ("*") to observe all keys.
+func (wc *WindowContext) OnChange(key string, fn func(old, new any)) {
+	if fn == nil {
+		return
+	}
+	wc.mu.Lock()
+	if wc.observers == nil {
+		wc.observers = make(map[string][]func(old, new any))
+	}
+	wc.observers[key] = append(wc.observers[key], fn)
+	wc.mu.Unlock()
+}
+
+// sameValue reports whether a and b are equal. Values of non-comparable types
+// (slices, maps, funcs) are always treated as changed.
+func sameValue(a, b any) (eq bool) {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ta := reflect.TypeOf(a)
+	if ta != reflect.TypeOf(b) || !ta.Comparable() {
+		return false
+	}
+	// comparable struct/array types may still hold non-comparable interface values
+	defer func() {
+		if recover() != nil {
+			eq = false
+		}
+	}()
+	return a == b
+}
+
+// deleteIf removes every entry whose value satisfies pred and notifies the
+// OnChange observers of each removed key with a nil new value.
+func (wc *WindowContext) deleteIf(pred func(v any) bool) {
+	type removal struct {
+		old any
+		obs []func(old, new any)
+	}
+	wc.mu.Lock()
+	var removed []removal
+	for k, v := range wc.m {
+		if !pred(v) {
+			continue
+		}
+		delete(wc.m, k)
+		var obs []func(old, new any)
+		obs = append(obs, wc.observers[k]...)
+		obs = append(obs, wc.observers[ContextWildcard]...)
+		removed = append(removed, removal{v, obs})
+	}
 	wc.mu.Unlock()
+	for _, r := range removed {
+		for _, fn := range r.obs {
+			fn(r.old, nil)
+		}
+	}
 }
 
 func (wc *WindowContext) Get(key string) (any, bool) {
@@ -27,6 +109,18 @@ func (wc *WindowContext) Get(key string) (any, bool) {
 	return v, ok
 }
 
+// Keys returns the stored keys in sorted order.
+func (wc *WindowContext) Keys() []string {
+	wc.mu.RLock()
+	keys := make([]string, 0, len(wc.m))
+	for k := range wc.m {
+		keys = append(keys, k)
+	}
+	wc.mu.RUnlock()
+	sort.Strings(keys)
+	return keys
+}
+
 // MustGet returns the value for key, panicking if missing or wrong type.
 func MustGet[T any](wc *WindowContext, key string) T {
 	wc.mu.RLock()
@@ -57,11 +151,13 @@ type Window struct {
 
 	// lifecycle + state
 	created       bool
+	readyFired    bool // OnReady has run
 	contentCalled bool
 	ctx           *WindowContext
 
 	// callbacks
 	onCreate  []func(*Window, *WindowContext)
+	onReady   []func(*Window, *WindowContext)
 	onStart   []func(*Window, *WindowContext)
 	onUpdate  []func(*Window, *WindowContext)
 	onResume  []func(*Window, *WindowContext)
@@ -70,6 +166,46 @@ type Window struct {
 	onDestroy []func(*Window, *WindowContext)
 	onResize  []func(*Window, *WindowContext, int, int)
 
+	onUserResize  []func(*Window, *WindowContext, int, int)
+	onResizeStart []func()
+	onResizeEnd   []func(w, h int)
+
+	onMouseEnter []func(*Window, *WindowContext)
+	onMouseLeave []func(*Window, *WindowContext)
+
+	onFrameOverrun []func(actual, budget time.Duration)
+	onAccentColor  []func(Color)
+	onMove         []func(x, y int)
+	onIdle         []idleHandler
+	onActive       []func()
+	onError        []func(stage string, recovered any)
+
+	onSessionLock   []func()
+	onSessionUnlock []func()
+	onPowerSuspend  []func()
+	onPowerResume   []func()
+	onOrientation   []func(orientation int)
+
+	// resize debounce (SetResizeDebounce); the debouncer only records the
+	// settled size, the loop then emits OnResize on its own goroutine
+	resizeDebounced ResizeHandler
+	resizePending   bool
+	resizePendingW  int
+	resizePendingH  int
+	resizeByUser    bool // a debounced resize includes a user resize
+
+	pollBatch  int // events per PollEvents call in the loop; 0 = defaultPollBatch
+	updateRate int // OnUpdate calls per second (SetUpdateRate); 0 = every frame
+	renderMode int // RenderContinuous or RenderOnDemand (SetRenderMode)
+	targetFPS  int // Run pacing (SetTargetFPS); 0 = package target, < 0 = unlimited
+
+	dragMove Rect // EnableDragMove region in client pixels; empty = off
+
+	closeReason CloseReason // why the last loop ended; see CloseReason
+
+	docName  string // SetDocumentTitle base name
+	docDirty bool   // unsaved-changes marker shown in the title
+
 	// optional content initializer (runs exactly once)
 	content func(*Window, *WindowContext)
 }
@@ -83,16 +219,213 @@ func InitWindowHandler() *Window {
 func (w *Window) Handle() Handle          { return GetMainWindow() }
 func (w *Window) Context() *WindowContext { return w.ctx }
 
+// HWND returns the Win32 window handle for calling APIs this package does not
+// wrap, or 0 before the window exists. The handle is only valid while the
+// window exists: do not keep it past OnDestroy, since Windows may reuse the
+// value for an unrelated window. The window belongs to the native UI thread,
+// so prefer PostMessage over SendMessage and avoid changing its window procedure.
+func (w *Window) HWND() uintptr { return GetWindowHandle() }
+
 // Run creates the native window if needed, applies queued properties,
 // and drives the lifecycle loop until closed or ctx canceled.
 func (w *Window) Run(ctx context.Context) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if !w.start() {
+		w.setCloseReason(CloseError)
+		return
+	}
+
+	// canceling ctx starts shutdown, whose close event also ends an
+	// on-demand wait
+	stopWatch := context.AfterFunc(ctx, BeginShutdownAsync)
+	defer stopWatch()
+
+	// Loop
+	st := w.newFrameState()
+	for !WindowShouldClose() {
+		if w.RenderMode() == RenderOnDemand {
+			w.frame(st, w.waitForFrame(st), time.Now())
+			continue
+		}
+		frameStart := time.Now()
+
+		// poll events and run update callbacks
+		w.frame(st, w.drainEvents(), frameStart)
+
+		// pace to the target, counting the frame's own work
+		paceSleep(w.frameBudget() - time.Since(frameStart))
+		recordFrameTime(time.Since(frameStart).Nanoseconds())
+	}
+
+	w.stop(ctx)
+}
+
+// CloseReason tells why a Run loop ended.
+type CloseReason int
+
+const (
+	CloseNone            CloseReason = iota // the loop has not ended
+	CloseUser                               // the user closed the window
+	CloseProgrammatic                       // CloseWindow, BeginShutdownAsync or Shutdown was called
+	CloseContextCanceled                    // the context passed to Run was canceled
+	CloseError                              // the runtime or window could not be started
+)
+
+func (r CloseReason) String() string {
+	switch r {
+	case CloseUser:
+		return "user"
+	case CloseProgrammatic:
+		return "programmatic"
+	case CloseContextCanceled:
+		return "context canceled"
+	case CloseError:
+		return "error"
+	}
+	return "none"
+}
+
+// CloseReason returns why the last Run, RunAsync or RunEventDriven loop
+// ended, or CloseNone while it is running. It is already set when OnStop and
+// OnDestroy run, so they can decide whether to save, prompt or restart.
+func (w *Window) CloseReason() CloseReason {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.closeReason
+}
+
+func (w *Window) setCloseReason(r CloseReason) {
+	w.mu.Lock()
+	w.closeReason = r
+	w.mu.Unlock()
+}
+
+// eventDrivenIdle bounds how long RunEventDriven sleeps without events, so
+// OnUpdate still runs periodically (timers, animations driven from it).
+const eventDrivenIdle = 500 * time.Millisecond
+
+// RunEventDriven is like Run but only runs a frame when native events arrive
+// (or after eventDrivenIdle without any), instead of spinning at the target
+// FPS. It suits mostly idle UIs such as editors and tools; OnUpdate must not
+// assume a steady frame rate.
+func (w *Window) RunEventDriven(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !w.start() {
+		w.setCloseReason(CloseError)
+		return
+	}
+	// canceling ctx starts shutdown, whose close event wakes the wait below
+	stopWatch := context.AfterFunc(ctx, BeginShutdownAsync)
+	defer stopWatch()
+
+	st := w.newFrameState()
+	for !WindowShouldClose() {
+		w.frame(st, w.waitForFrame(st), time.Now())
+	}
+
+	w.stop(ctx)
+}
+
+// waitForFrame blocks until the next event-driven frame is due (native events,
+// RequestRender, a deferred OnUpdate or eventDrivenIdle without any of them)
+// and returns its events.
+func (w *Window) waitForFrame(st *frameState) []Event {
+	wait := eventDrivenIdle
+	if st.skipped {
+		// wake for the deferred OnUpdate
+		wait = max(min(wait, time.Until(st.nextUpdate)), 0)
+	}
+	evs, _ := WaitForEvent(wait)
+	return append(evs, w.drainEvents()...)
+}
+
+// Render modes for SetRenderMode.
+const (
+	RenderContinuous = 0 // run frames at the target FPS (default)
+	RenderOnDemand   = 1 // run a frame only when something happened
+)
+
+// SetRenderMode selects how Run paces frames. RenderContinuous runs one per
+// target-FPS interval whether or not anything changed. RenderOnDemand sleeps
+// until input, a resize or another window event, a RequestRender call, or
+// the idle timer (twice a second, for OnIdle and timers polled from OnUpdate),
+// which saves power in mostly idle UIs; RunEventDriven always behaves this
+// way. The mode can be switched while the loop runs. Unknown modes are ignored.
+func (w *Window) SetRenderMode(mode int) {
+	if mode != RenderContinuous && mode != RenderOnDemand {
+		return
+	}
+	w.mu.Lock()
+	w.renderMode = mode
+	w.mu.Unlock()
+}
+
+// RenderMode returns the mode set by SetRenderMode.
+func (w *Window) RenderMode() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.renderMode
+}
+
+// RequestRender makes an on-demand loop run a frame soon, e.g. after a
+// goroutine changed state that OnUpdate draws. It is safe to call from any
+// goroutine, and calls made before that frame are merged into it. It has no
+// effect in continuous mode, and with a DLL lacking winui_post_wake_event the
+// frame waits for the idle timer.
+func (w *Window) RequestRender() {
+	if pPostWakeEvent == nil {
+		return
+	}
+	pPostWakeEvent.Call()
+}
+
+// WaitForEvent blocks until native events are pending or timeout elapses;
+// see the package-level WaitForEvent.
+func (w *Window) WaitForEvent(timeout time.Duration) ([]Event, bool) {
+	return WaitForEvent(timeout)
+}
+
+// Loop polling limits.
+const (
+	defaultPollBatch = 64
+	maxPollRounds    = 16 // bounds one frame's drain under an input flood
+)
+
+// drainEvents polls the native queue in batches of the configured size while
+// more events are pending, so a backlog is handled in a single frame.
+func (w *Window) drainEvents() []Event {
+	w.mu.RLock()
+	n := w.pollBatch
+	w.mu.RUnlock()
+	if n <= 0 {
+		n = defaultPollBatch
+	}
+	var all []Event
+	for range maxPollRounds {
+		evs, more := PollEvents(n)
+		all = append(all, evs...)
+		if !more {
+			break
+		}
+	}
+	return all
+}
+
+// start initializes the runtime and window, applies queued configuration and
+// emits OnCreate (once), content and OnStart. Returns false if Init failed.
+func (w *Window) start() bool {
+	w.setCloseReason(CloseNone)
+	// a Shutdown from a previous loop must not mark this one programmatic
+	shutdownByAPI.Store(false)
 	// Ensure runtime initialized
 	if err := Init(); err != nil {
 		// best-effort: if init fails, return
-		return
+		logf(LogError, "Run: %v", err)
+		return false
 	}
 
 	// Create window if missing
@@ -113,7 +446,9 @@ func (w *Window) Run(ctx context.Context) {
 		_, _ = CreateWindowAndWait(tw, th, t, 5*time.Second)
 	} else {
 		// Wait until ready if already in-flight
-		_ = WaitUntilWindowReady(5 * time.Second)
+		if WaitUntilWindowReady(5*time.Second) == nil {
+			applyPendingDWM()
+		}
 	}
 
 	// Apply queued configuration and emit OnCreate once
@@ -138,102 +473,406 @@ func (w *Window) Run(ctx context.Context) {
 		cbs := append([]func(*Window, *WindowContext){}, w.onCreate...)
 		w.mu.Unlock()
 		for _, fn := range cbs {
-			w.safeCall(func() { fn(w, w.ctx) })
+			w.safeCall("OnCreate", func() { fn(w, w.ctx) })
 		}
 		w.mu.Lock()
 		if w.content != nil && !w.contentCalled {
 			fn := w.content
 			w.contentCalled = true
 			w.mu.Unlock()
-			w.safeCall(func() { fn(w, w.ctx) })
+			w.safeCall("SetContent", func() { fn(w, w.ctx) })
 			w.mu.Lock()
 		}
 	}
 	w.mu.Unlock()
 
 	// Start
-	w.emitSimple(w.onStart)
+	w.emitSimple("OnStart", w.onStart)
+	return true
+}
 
-	// Loop
-	prevFocused := IsWindowFocused()
-	for {
-		select {
-		case <-ctx.Done():
-			BeginShutdownAsync()
-		default:
+// stop records why the loop ended, then emits OnStop and OnDestroy.
+func (w *Window) stop(ctx context.Context) {
+	switch {
+	case ctx.Err() != nil:
+		w.setCloseReason(CloseContextCanceled)
+	case shutdownByAPI.Load():
+		w.setCloseReason(CloseProgrammatic)
+	default:
+		w.setCloseReason(CloseUser)
+	}
+	w.emitSimple("OnStop", w.onStop)
+	w.emitSimple("OnDestroy", w.onDestroy)
+}
+
+// frameState carries the transition tracking of a running loop.
+type frameState struct {
+	prevFocused bool
+	prevHover   bool
+	orientation int       // last display orientation seen (OnOrientationChanged)
+	idle        bool      // an OnIdle threshold was reached since the last input
+	started     time.Time // idle reference before any input arrived
+	nextUpdate  time.Time // earliest OnUpdate under SetUpdateRate
+	skipped     bool      // the last frame deferred OnUpdate
+}
+
+func (w *Window) newFrameState() *frameState {
+	return &frameState{prevFocused: IsWindowFocused(), prevHover: isMouseInWindow(), orientation: GetDisplayOrientation(), started: time.Now()}
+}
+
+// idleHandler is an OnIdle registration.
+type idleHandler struct {
+	fn        func(idleFor time.Duration)
+	threshold time.Duration
+}
+
+// frame runs one lifecycle iteration for the polled events: transition
+// callbacks, OnUpdate, per-frame input reset and overrun reporting.
+func (w *Window) frame(st *frameState, evs []Event, frameStart time.Time) {
+	// RunOnUIThread calls queued for MainLoop
+	runUICalls()
+
+	w.checkReady()
+
+	for _, ev := range evs {
+		if ev.Kind == EventKindAccentColorChanged {
+			w.emitAccentColor(GetSystemAccentColor())
+			break // one notification per frame is enough
 		}
-		if WindowShouldClose() {
-			break
+	}
+
+	// session and power notifications, in arrival order
+	for _, ev := range evs {
+		switch {
+		case ev.Kind == EventKindSession && ev.Action == ActionSessionLock:
+			w.emitFuncs("OnSessionLock", w.onSessionLock)
+		case ev.Kind == EventKindSession && ev.Action == ActionSessionUnlock:
+			w.emitFuncs("OnSessionUnlock", w.onSessionUnlock)
+		case ev.Kind == EventKindPower && ev.Action == ActionPowerSuspend:
+			w.emitFuncs("OnPowerSuspend", w.onPowerSuspend)
+		case ev.Kind == EventKindPower && ev.Action == ActionPowerResume:
+			w.emitFuncs("OnPowerResume", w.onPowerResume)
 		}
+	}
 
-		// poll events and run update callbacks
-		_, _ = PollEvents(64)
+	// display rotation
+	for _, ev := range evs {
+		if ev.Kind == EventKindDisplayChanged {
+			if o := GetDisplayOrientation(); o != st.orientation {
+				st.orientation = o
+				w.emitOrientation(o)
+			}
+			break
+		}
+	}
 
-		// forward resize into lifecycle if it occurred
-		if IsWindowResized() {
-			cw, ch := GetWindowClientSize()
-			w.emitResize(cw, ch)
+	// forward resize into lifecycle if it occurred
+	if IsWindowResized() {
+		cw, ch := GetWindowClientSize()
+		byUser := IsWindowResizedByUser()
+		w.mu.Lock()
+		debounced := w.resizeDebounced
+		if debounced != nil && byUser {
+			w.resizeByUser = true
 		}
+		w.mu.Unlock()
+		if debounced != nil {
+			debounced(cw, ch)
+		} else {
+			w.emitResize(cw, ch, byUser)
+		}
+	}
+	if cw, ch, byUser, ok := w.takePendingResize(); ok {
+		w.emitResize(cw, ch, byUser)
+	}
 
-		// focus transitions
-		curFocused := IsWindowFocused()
-		if curFocused && !prevFocused {
-			w.emitSimple(w.onResume)
-		} else if !curFocused && prevFocused {
-			w.emitSimple(w.onPause)
+	// user border drags, after the resizes they caused
+	for _, ev := range evs {
+		if ev.Kind != EventKindResizeDrag {
+			continue
+		}
+		switch ev.Action {
+		case ActionResizeStart:
+			w.emitFuncs("OnResizeStart", w.onResizeStart)
+		case ActionResizeEnd:
+			w.emitResizeEnd(GetWindowClientSize())
 		}
-		prevFocused = curFocused
+	}
+
+	// forward moves (one per frame, with the final position)
+	if IsWindowMoved() {
+		w.emitMove(GetWindowMovePosition())
+	}
 
-		// OnUpdate
-		w.emitSimple(w.onUpdate)
+	// focus transitions
+	curFocused := IsWindowFocused()
+	if curFocused && !st.prevFocused {
+		w.emitSimple("OnResume", w.onResume)
+	} else if !curFocused && st.prevFocused {
+		w.emitSimple("OnPause", w.onPause)
+	}
+	st.prevFocused = curFocused
 
-		// Clear per-frame transitions after update
-		ResetKeyTransitions()
+	// hover transitions (native pointer enter/leave)
+	curHover := isMouseInWindow()
+	if curHover && !st.prevHover {
+		w.emitSimple("OnMouseEnter", w.onMouseEnter)
+	} else if !curHover && st.prevHover {
+		w.emitSimple("OnMouseLeave", w.onMouseLeave)
+	}
+	st.prevHover = curHover
 
-		// Pace similar to Run()
-		fps := GetFPS()
-		if fps <= 0 {
-			fps = 60
-		}
-		time.Sleep(time.Duration(float64(time.Second) / float64(fps)))
+	// idle / active transitions
+	w.checkIdle(st)
+
+	// OnUpdate is throttled by SetUpdateRate; a deferred update keeps the
+	// frame's input transitions for the next one
+	if st.skipped = !w.updateDue(st, frameStart); st.skipped {
+		resetWindowFlags()
+		return
 	}
 
-	// Stop + Destroy
-	w.emitSimple(w.onStop)
-	w.emitSimple(w.onDestroy)
+	// drag-to-move region (EnableDragMove)
+	w.checkDragMove()
+
+	// OnUpdate
+	w.emitSimple("OnUpdate", w.onUpdate)
+
+	// Clear per-frame transitions after update
+	ResetKeyTransitions()
+
+	// Report frames whose work alone exceeded the target FPS budget
+	if work, budget := time.Since(frameStart), w.frameBudget(); budget > 0 && work > budget {
+		w.emitFrameOverrun(work, budget)
+	}
 }
 
-// emitSimple invokes callbacks with panic recovery.
-func (w *Window) emitSimple(fns []func(*Window, *WindowContext)) {
+// updateDue reports whether OnUpdate should run in the frame starting at now
+// and schedules the next one.
+func (w *Window) updateDue(st *frameState, now time.Time) bool {
+	w.mu.RLock()
+	hz := w.updateRate
+	w.mu.RUnlock()
+	if hz <= 0 {
+		return true
+	}
+	if now.Before(st.nextUpdate) {
+		return false
+	}
+	interval := time.Duration(math.Round(1e9 / float64(hz)))
+	st.nextUpdate = st.nextUpdate.Add(interval)
+	if st.nextUpdate.Before(now) {
+		// fell behind (or first update): no catch-up burst
+		st.nextUpdate = now.Add(interval)
+	}
+	return true
+}
+
+// RunAsync starts Run(ctx) on a new goroutine and returns a channel that is
+// closed when the loop exits (window closed or ctx canceled).
+//
+// Threading: the goroutine is pinned with runtime.LockOSThread for its whole
+// lifetime, so Init and every lifecycle callback run on one OS thread. The
+// XAML tree itself lives on the native UI thread (a COM STA owned by
+// WinUI3Native.dll); wrappers marshal to it, so callbacks must not assume they
+// run on the UI thread. Do not call Run or RunAsync again while a loop is active.
+func (w *Window) RunAsync(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(done)
+		w.Run(ctx)
+	}()
+	return done
+}
+
+// emitSimple invokes callbacks with panic recovery; stage names them for OnError.
+func (w *Window) emitSimple(stage string, fns []func(*Window, *WindowContext)) {
 	w.mu.RLock()
 	cbs := append([]func(*Window, *WindowContext){}, fns...)
 	w.mu.RUnlock()
 	for _, fn := range cbs {
-		w.safeCall(func() { fn(w, w.ctx) })
+		w.safeCall(stage, func() { fn(w, w.ctx) })
 	}
 }
 
-func (w *Window) emitResize(width, height int) {
+// emitFuncs is emitSimple for callbacks without arguments.
+func (w *Window) emitFuncs(stage string, fns []func()) {
+	w.mu.RLock()
+	cbs := append([]func(){}, fns...)
+	w.mu.RUnlock()
+	for _, fn := range cbs {
+		w.safeCall(stage, fn)
+	}
+}
+
+func (w *Window) emitResize(width, height int, byUser bool) {
 	w.mu.RLock()
 	cbs := append([]func(*Window, *WindowContext, int, int){}, w.onResize...)
+	var user []func(*Window, *WindowContext, int, int)
+	if byUser {
+		user = append(user, w.onUserResize...)
+	}
+	w.mu.RUnlock()
+	for _, fn := range cbs {
+		w.safeCall("OnResize", func() { fn(w, w.ctx, width, height) })
+	}
+	for _, fn := range user {
+		w.safeCall("OnUserResize", func() { fn(w, w.ctx, width, height) })
+	}
+}
+
+// checkReady emits OnReady once, in the first frame the native window reports
+// itself ready.
+func (w *Window) checkReady() {
+	w.mu.RLock()
+	fired := w.readyFired
+	w.mu.RUnlock()
+	if fired || !IsWindowReady() {
+		return
+	}
+	w.mu.Lock()
+	w.readyFired = true
+	w.mu.Unlock()
+	// a DPI read before the window settled may have fallen back to 96
+	RefreshDPICache()
+	w.emitSimple("OnReady", w.onReady)
+}
+
+// checkIdle runs the OnIdle handlers whose threshold has passed since the
+// last input and OnActive once input resumes after an idle period.
+func (w *Window) checkIdle(st *frameState) {
+	w.mu.RLock()
+	handlers := append([]idleHandler{}, w.onIdle...)
+	active := append([]func(){}, w.onActive...)
+	w.mu.RUnlock()
+	if len(handlers) == 0 {
+		return
+	}
+	last := st.started
+	if t := lastInputTime(); t.After(last) {
+		last = t
+	}
+	idleFor := time.Since(last)
+	idle := false
+	for _, h := range handlers {
+		if idleFor >= h.threshold {
+			idle = true
+			w.safeCall("OnIdle", func() { h.fn(idleFor) })
+		}
+	}
+	if st.idle && !idle {
+		for _, fn := range active {
+			w.safeCall("OnActive", fn)
+		}
+	}
+	st.idle = idle
+}
+
+func (w *Window) emitMove(x, y int) {
+	w.mu.RLock()
+	cbs := append([]func(int, int){}, w.onMove...)
+	w.mu.RUnlock()
+	for _, fn := range cbs {
+		w.safeCall("OnMove", func() { fn(x, y) })
+	}
+}
+
+// takePendingResize returns and clears a size settled by the resize debouncer,
+// and whether any resize folded into it was made by the user.
+func (w *Window) takePendingResize() (width, height int, byUser, ok bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.resizePending {
+		return 0, 0, false, false
+	}
+	w.resizePending = false
+	byUser, w.resizeByUser = w.resizeByUser, false
+	return w.resizePendingW, w.resizePendingH, byUser, true
+}
+
+func (w *Window) emitFrameOverrun(actual, budget time.Duration) {
+	w.mu.RLock()
+	cbs := append([]func(time.Duration, time.Duration){}, w.onFrameOverrun...)
+	w.mu.RUnlock()
+	for _, fn := range cbs {
+		w.safeCall("OnFrameOverrun", func() { fn(actual, budget) })
+	}
+}
+
+func (w *Window) emitAccentColor(c Color) {
+	w.mu.RLock()
+	cbs := append([]func(Color){}, w.onAccentColor...)
+	w.mu.RUnlock()
+	for _, fn := range cbs {
+		w.safeCall("OnAccentColorChanged", func() { fn(c) })
+	}
+}
+
+func (w *Window) emitResizeEnd(width, height int) {
+	w.mu.RLock()
+	cbs := append([]func(int, int){}, w.onResizeEnd...)
 	w.mu.RUnlock()
 	for _, fn := range cbs {
-		w.safeCall(func() { fn(w, w.ctx, width, height) })
+		w.safeCall("OnResizeEnd", func() { fn(width, height) })
 	}
 }
 
-func (w *Window) safeCall(fn func()) {
-	defer func() { _ = recover() }()
+func (w *Window) emitOrientation(o int) {
+	w.mu.RLock()
+	cbs := append([]func(int){}, w.onOrientation...)
+	w.mu.RUnlock()
+	for _, fn := range cbs {
+		w.safeCall("OnOrientationChanged", func() { fn(o) })
+	}
+}
+
+// CallbackPanic is the value OnError receives for a panicking callback: the
+// recovered value and the stack of the panicking goroutine.
+type CallbackPanic struct {
+	Value any
+	Stack []byte
+}
+
+func (p *CallbackPanic) Error() string { return fmt.Sprintf("callback panic: %v", p.Value) }
+
+// Unwrap returns Value if it is an error, so errors.Is/As see through the panic.
+func (p *CallbackPanic) Unwrap() error { err, _ := p.Value.(error); return err }
+
+// safeCall runs a user callback, recovering a panic so the loop survives. The
+// panic is reported to the OnError handlers and the logger.
+func (w *Window) safeCall(stage string, fn func()) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		p := &CallbackPanic{Value: r, Stack: debug.Stack()}
+		logf(LogError, "%s callback panicked: %v\n%s", stage, r, p.Stack)
+		w.mu.RLock()
+		handlers := append([]func(string, any){}, w.onError...)
+		w.mu.RUnlock()
+		for _, h := range handlers {
+			func() {
+				defer func() { _ = recover() }() // a failing error handler must not kill the loop
+				h(stage, p)
+			}()
+		}
+	}()
 	fn()
 }
 
 // SetContent registers content initializer to run once (pre or post creation).
+// Calling it again after the content ran replaces the function used by
+// RebuildContent and runs the new one immediately.
 func (w *Window) SetContent(fn func(*Window, *WindowContext)) {
 	w.mu.Lock()
 	if w.contentCalled {
+		w.content = fn
 		w.mu.Unlock()
 		// Already ran; invoke immediately for ergonomics
-		w.safeCall(func() { fn(w, w.ctx) })
+		w.safeCall("SetContent", func() { fn(w, w.ctx) })
 		return
 	}
 	w.content = fn
@@ -242,18 +881,49 @@ func (w *Window) SetContent(fn func(*Window, *WindowContext)) {
 		w.contentCalled = true
 		f := w.content
 		w.mu.Unlock()
-		w.safeCall(func() { f(w, w.ctx) })
+		w.safeCall("SetContent", func() { f(w, w.ctx) })
 		return
 	}
 	w.mu.Unlock()
 }
 
+// RebuildContent clears all controls from the window and runs the content
+// function again, e.g. to switch views. Handle values stored in the
+// WindowContext are removed since they refer to released controls (OnChange
+// observers see the new value as nil). Control creation is marshalled to the
+// UI thread by the native layer. Returns false if the window is not created,
+// no content function is set, or the DLL cannot clear content.
+func (w *Window) RebuildContent() bool {
+	w.mu.Lock()
+	fn, created := w.content, w.created
+	w.mu.Unlock()
+	if !created || fn == nil || ClearWindowContent() < 0 {
+		return false
+	}
+	w.ctx.deleteIf(func(v any) bool { _, ok := v.(Handle); return ok })
+	w.mu.Lock()
+	w.contentCalled = true
+	w.mu.Unlock()
+	w.safeCall("RebuildContent", func() { fn(w, w.ctx) })
+	return true
+}
+
 // Callback registration -----------------------------------------------------
 func (w *Window) OnCreate(fn func(*Window, *WindowContext)) {
 	w.mu.Lock()
 	w.onCreate = append(w.onCreate, fn)
 	w.mu.Unlock()
 }
+
+// OnReady registers fn to run once, from the loop, in the first frame in
+// which the window exists and has content (IsWindowReady). It follows OnCreate
+// and OnStart; unlike them it guarantees that DPI, size and position queries
+// report the real window.
+func (w *Window) OnReady(fn func(*Window, *WindowContext)) {
+	w.mu.Lock()
+	w.onReady = append(w.onReady, fn)
+	w.mu.Unlock()
+}
 func (w *Window) OnStart(fn func(*Window, *WindowContext)) {
 	w.mu.Lock()
 	w.onStart = append(w.onStart, fn)
@@ -290,6 +960,220 @@ func (w *Window) OnResize(fn func(*Window, *WindowContext, int, int)) {
 	w.mu.Unlock()
 }
 
+// OnUserResize is like OnResize but skips resizes made by the program through
+// SetSize, SetWindowSize and the other size setters, so relayout driven by
+// the user's frame drags (or maximize, snap, DPI changes) cannot feed back
+// into itself. It honors SetResizeDebounce: a debounced resize counts as the
+// user's if any resize folded into it was.
+func (w *Window) OnUserResize(fn func(*Window, *WindowContext, int, int)) {
+	w.mu.Lock()
+	w.onUserResize = append(w.onUserResize, fn)
+	w.mu.Unlock()
+}
+
+// OnIdle registers fn to be called from the loop, every frame, while no key
+// or mouse input has arrived for at least threshold; idleFor is the time since
+// the last input (or since the loop started). Use it to dim the UI or pause
+// animations. Under RunEventDriven an idle frame runs at least twice a second.
+func (w *Window) OnIdle(fn func(idleFor time.Duration), threshold time.Duration) {
+	w.mu.Lock()
+	w.onIdle = append(w.onIdle, idleHandler{fn: fn, threshold: threshold})
+	w.mu.Unlock()
+}
+
+// OnError registers fn to be called when a lifecycle callback panics. The
+// panic is still recovered so the loop keeps running; stage names the
+// callback ("OnUpdate", "OnResize", "SetContent", ...) and recovered is a
+// *CallbackPanic carrying the panic value and stack. Panics in fn itself are
+// ignored.
+func (w *Window) OnError(fn func(stage string, recovered any)) {
+	w.mu.Lock()
+	w.onError = append(w.onError, fn)
+	w.mu.Unlock()
+}
+
+// OnActive registers fn to be called once when input resumes after an OnIdle
+// threshold was reached.
+func (w *Window) OnActive(fn func()) {
+	w.mu.Lock()
+	w.onActive = append(w.onActive, fn)
+	w.mu.Unlock()
+}
+
+// OnSessionLock / OnSessionUnlock register fn to be called when the user
+// locks or unlocks the session (Win+L, switching users, remote disconnect).
+func (w *Window) OnSessionLock(fn func()) {
+	w.mu.Lock()
+	w.onSessionLock = append(w.onSessionLock, fn)
+	w.mu.Unlock()
+}
+func (w *Window) OnSessionUnlock(fn func()) {
+	w.mu.Lock()
+	w.onSessionUnlock = append(w.onSessionUnlock, fn)
+	w.mu.Unlock()
+}
+
+// OnPowerSuspend registers fn to be called when the machine is about to
+// sleep or hibernate. The system allows about two seconds after the
+// notification, and the callback runs on the next frame, so keep it short.
+func (w *Window) OnPowerSuspend(fn func()) {
+	w.mu.Lock()
+	w.onPowerSuspend = append(w.onPowerSuspend, fn)
+	w.mu.Unlock()
+}
+
+// OnPowerResume registers fn to be called when the machine has resumed from
+// sleep or hibernation, whether or not the user is present yet.
+func (w *Window) OnPowerResume(fn func()) {
+	w.mu.Lock()
+	w.onPowerResume = append(w.onPowerResume, fn)
+	w.mu.Unlock()
+}
+
+// SetUpdateRate limits OnUpdate to hz calls per second while the loop keeps
+// polling events at the target FPS, so resize, focus and other callbacks stay
+// responsive and input state stays current. Key and mouse transitions
+// (IsKeyPressed, GetKeyPressed, ...) accumulate between updates, so none are
+// missed. hz <= 0 restores the default of one update per frame.
+func (w *Window) SetUpdateRate(hz int) {
+	w.mu.Lock()
+	w.updateRate = max(hz, 0)
+	w.mu.Unlock()
+}
+
+// SetTargetFPS sets the frame rate Run paces this window at, overriding the
+// package-level SetTargetFPS (which RunPacedLoop and the other loops use).
+// fps <= 0 follows the package-level target again; values above 1000 are
+// clamped. It also sets the OnFrameOverrun budget.
+func (w *Window) SetTargetFPS(fps int) {
+	w.mu.Lock()
+	w.targetFPS = min(max(fps, 0), 1000)
+	w.mu.Unlock()
+}
+
+// SetUnlimitedFPS makes Run start each frame as soon as the previous one ends,
+// e.g. for benchmarks. It keeps a CPU core busy; OnFrameOverrun never fires.
+// SetTargetFPS restores pacing.
+func (w *Window) SetUnlimitedFPS() {
+	w.mu.Lock()
+	w.targetFPS = -1
+	w.mu.Unlock()
+}
+
+// frameBudget returns the frame duration for this window's pacing; 0 when
+// unlimited.
+func (w *Window) frameBudget() time.Duration {
+	w.mu.RLock()
+	fps := w.targetFPS
+	w.mu.RUnlock()
+	switch {
+	case fps < 0:
+		return 0
+	case fps == 0:
+		return frameBudget()
+	}
+	return time.Duration(math.Round(1e9 / float64(fps)))
+}
+
+// SetPollBatchSize sets how many events the loop fetches per PollEvents call
+// (default 64). The loop keeps polling while events remain, up to 16 batches
+// per frame, so the size only trades call count against buffer size.
+// n <= 0 restores the default.
+func (w *Window) SetPollBatchSize(n int) {
+	w.mu.Lock()
+	w.pollBatch = n
+	w.mu.Unlock()
+}
+
+// OnMove registers fn to be called from the loop when the window moved, at
+// most once per frame, with the new outer window origin in screen pixels.
+// Useful to persist the window placement.
+func (w *Window) OnMove(fn func(x, y int)) {
+	w.mu.Lock()
+	w.onMove = append(w.onMove, fn)
+	w.mu.Unlock()
+}
+
+// SetResizeDebounce delays OnResize until the client size has been stable for
+// d, so expensive relayout runs once per drag-resize instead of every frame.
+// The callback still runs on the loop goroutine, in the first frame after the
+// size settles (the next wake-up under RunEventDriven), with the final size.
+// IsWindowResized keeps reporting every resizing frame regardless. d <= 0
+// (the default) fires OnResize immediately in each frame a resize occurred.
+func (w *Window) SetResizeDebounce(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if d <= 0 {
+		w.resizeDebounced = nil
+		w.resizePending, w.resizeByUser = false, false
+		return
+	}
+	w.resizeDebounced = debounceResize(func(width, height int) {
+		w.mu.Lock()
+		w.resizePending, w.resizePendingW, w.resizePendingH = true, width, height
+		w.mu.Unlock()
+	}, d)
+}
+
+// OnMouseEnter / OnMouseLeave fire from the loop when the cursor enters or
+// leaves the client area (checked once per frame).
+func (w *Window) OnMouseEnter(fn func(*Window, *WindowContext)) {
+	w.mu.Lock()
+	w.onMouseEnter = append(w.onMouseEnter, fn)
+	w.mu.Unlock()
+}
+func (w *Window) OnMouseLeave(fn func(*Window, *WindowContext)) {
+	w.mu.Lock()
+	w.onMouseLeave = append(w.onMouseLeave, fn)
+	w.mu.Unlock()
+}
+
+// OnFrameOverrun registers fn to be called when a frame's work (event polling,
+// lifecycle callbacks and OnUpdate, excluding pacing sleep) takes longer than
+// the budget implied by SetTargetFPS. Useful to spot jank.
+func (w *Window) OnFrameOverrun(fn func(actual, budget time.Duration)) {
+	w.mu.Lock()
+	w.onFrameOverrun = append(w.onFrameOverrun, fn)
+	w.mu.Unlock()
+}
+
+// OnAccentColorChanged registers fn to be called with the new accent color
+// (see GetSystemAccentColor) when the user changes it in the system settings.
+func (w *Window) OnAccentColorChanged(fn func(Color)) {
+	w.mu.Lock()
+	w.onAccentColor = append(w.onAccentColor, fn)
+	w.mu.Unlock()
+}
+
+// OnResizeStart registers fn to be called when the user starts resizing the
+// window by dragging its border, e.g. to lower rendering quality until
+// OnResizeEnd. Moving the window, maximizing and SetSize do not trigger it.
+func (w *Window) OnResizeStart(fn func()) {
+	w.mu.Lock()
+	w.onResizeStart = append(w.onResizeStart, fn)
+	w.mu.Unlock()
+}
+
+// OnResizeEnd registers fn to be called once with the final client size when
+// the user releases the border after a resize drag. Unlike SetResizeDebounce it
+// does not guess from a pause, so it is the trigger for expensive relayout or
+// saving the size. Resizes made any other way only report OnResize.
+func (w *Window) OnResizeEnd(fn func(w, h int)) {
+	w.mu.Lock()
+	w.onResizeEnd = append(w.onResizeEnd, fn)
+	w.mu.Unlock()
+}
+
+// OnOrientationChanged registers fn to be called with the new orientation
+// (see GetDisplayOrientation) when the display showing the window is rotated,
+// e.g. to relayout a tablet app. Resolution changes that keep the orientation
+// do not trigger it.
+func (w *Window) OnOrientationChanged(fn func(orientation int)) {
+	w.mu.Lock()
+	w.onOrientation = append(w.onOrientation, fn)
+	w.mu.Unlock()
+}
+
 // Config/properties ---------------------------------------------------------
 func (w *Window) SetTitle(title string) {
 	w.mu.Lock()
@@ -301,6 +1185,47 @@ func (w *Window) SetTitle(title string) {
 	}
 }
 
+// SetDocumentTitle sets the title to name, prefixed with "• " while dirty
+// reports unsaved changes, the usual editor convention. The name is kept, so
+// SetDocumentDirty can toggle the marker later without passing it again.
+func (w *Window) SetDocumentTitle(name string, dirty bool) {
+	w.mu.Lock()
+	w.docName, w.docDirty = name, dirty
+	w.mu.Unlock()
+	w.SetTitle(documentTitle(name, dirty))
+}
+
+// SetDocumentDirty updates the unsaved-changes marker of the title set by
+// SetDocumentTitle. Without one, the title from SetTitle becomes the document
+// name; with no title at all only the state is recorded.
+func (w *Window) SetDocumentDirty(dirty bool) {
+	w.mu.Lock()
+	if w.docName == "" && w.title != nil {
+		w.docName = *w.title
+	}
+	name := w.docName
+	w.docDirty = dirty
+	w.mu.Unlock()
+	if name == "" {
+		return
+	}
+	w.SetTitle(documentTitle(name, dirty))
+}
+
+// IsDocumentDirty reports the marker state of SetDocumentTitle.
+func (w *Window) IsDocumentDirty() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.docDirty
+}
+
+func documentTitle(name string, dirty bool) string {
+	if dirty {
+		return "• " + name
+	}
+	return name
+}
+
 func (w *Window) SetBackgroundColor(c Color) {
 	w.mu.Lock()
 	w.bgColor = &c
@@ -311,7 +1236,8 @@ func (w *Window) SetBackgroundColor(c Color) {
 	}
 }
 
-// SetSize sets desired client size.
+// SetSize sets desired client size, clamped to the min/max size once the
+// window exists (see SetWindowSize).
 func (w *Window) SetSize(width, height int) {
 	w.mu.Lock()
 	w.sizeW, w.sizeH = &width, &height
@@ -376,7 +1302,9 @@ func (w *Window) OuterSize() (int, int)  { return GetWindowOuterSize() }
 
 // Position, DPI, and state
 func (w *Window) GetPosition() (int, int)      { return GetWindowPosition() }
+func (w *Window) ClientPosition() (int, int)   { return GetWindowClientPosition() }
 func (w *Window) SetPosition(x, y int)         { SetWindowPosition(x, y) }
+func (w *Window) MoveBy(dx, dy int)            { MoveWindowBy(dx, dy) }
 func (w *Window) DPIScale() (float64, float64) { return GetWindowScaleDPI() }
 func (w *Window) IsFullscreen() bool           { return IsWindowFullscreen() }
 func (w *Window) ToggleFullscreen()            { ToggleFullscreen() }
@@ -384,6 +1312,72 @@ func (w *Window) ToggleBorderlessWindowed()    { ToggleFullscreen() }
 func (w *Window) MaximizeWindow()              { MaximizeWindow() }
 func (w *Window) MinimizeWindow()              { MinimizeWindow() }
 func (w *Window) RestoreWindow()               { RestoreWindow() }
+func (w *Window) ForceToFront() bool           { return ForceWindowToFront() }
+func (w *Window) Opacity() float64             { return GetWindowOpacity() }
+func (w *Window) SetClickThrough(on bool)      { SetWindowClickThrough(on) }
+func (w *Window) SetNoActivate(on bool)        { SetWindowNoActivate(on) }
+func (w *Window) SetMaximizeEnabled(on bool)   { SetWindowMaximizeEnabled(on) }
+func (w *Window) SetMinimizeEnabled(on bool)   { SetWindowMinimizeEnabled(on) }
+func (w *Window) ShowNoActivate()              { ShowWindowNoActivate() }
+func (w *Window) SetRegion(r WindowRegion)     { SetWindowRegion(r) }
+func (w *Window) ClearRegion()                 { ClearWindowRegion() }
+func (w *Window) SetColorKey(c Color)          { SetWindowColorKey(c) }
+func (w *Window) ClearColorKey()               { ClearWindowColorKey() }
+func (w *Window) Fade(target float64, duration time.Duration) {
+	FadeWindow(target, duration)
+}
+func (w *Window) MoveAnimated(x, y int, duration time.Duration, easing Easing) {
+	MoveWindowAnimated(x, y, duration, easing)
+}
+func (w *Window) NormalRect() (x, y, width, height int) { return GetWindowNormalRect() }
+func (w *Window) SetNormalRect(x, y, width, height int) {
+	SetWindowNormalRect(x, y, width, height)
+}
+
+func (w *Window) SetMinimizeToTray(on bool) { SetMinimizeToTray(on) }
+
+// Taskbar button progress
+func (w *Window) SetTaskbarProgress(value, max float64) { SetTaskbarProgress(value, max) }
+func (w *Window) SetTaskbarProgressState(state int)     { SetTaskbarProgressState(state) }
+
+// Coordinate conversion (client <-> screen pixels)
+func (w *Window) ClientToScreen(x, y int) (int, int) { return ClientToScreen(x, y) }
+func (w *Window) ScreenToClient(x, y int) (int, int) { return ScreenToClient(x, y) }
+
+// ShowModal shows child (a top-level HWND such as a dialog created by other
+// code) modal to this window: the window is disabled until child is
+// destroyed. See SetWindowModal; returns nil if either window is missing.
+//
+// child is a raw HWND rather than a *Window because the runtime hosts a
+// single native window: every Window drives that same HWND (GetWindowHandle),
+// so a second *Window could only ever name the parent itself.
+func (w *Window) ShowModal(child uintptr) <-chan struct{} {
+	done := SetWindowModal(GetWindowHandle(), child)
+	if done != nil && procShowWindow.Find() == nil {
+		procShowWindow.Call(child, uintptr(SW_SHOW))
+	}
+	return done
+}
+
+// Appearance (DWM)
+func (w *Window) SetCornerPreference(pref int) { SetWindowCornerPreference(pref) }
+func (w *Window) SetBackdrop(material int)     { SetWindowBackdrop(material) }
+func (w *Window) SetDarkTitleBar(dark bool)    { SetWindowDarkTitleBar(dark) }
+func (w *Window) SetTitleBarColors(caption, text, border Color) {
+	SetTitleBarColors(caption, text, border)
+}
+func (w *Window) SetAnimationsEnabled(on bool) { SetWindowAnimationsEnabled(on) }
+func (w *Window) SetBlur(on bool, tint Color)  { SetWindowBlur(on, tint) }
+
+// SetInTaskSwitcher shows or hides the window in Alt+Tab and the taskbar.
+func (w *Window) SetInTaskSwitcher(on bool) { SetWindowInTaskSwitcher(on) }
+
+// Custom title bar
+func (w *Window) SetCustomTitleBar(on bool) { SetCustomTitleBar(on) }
+func (w *Window) SetTitleBarDragRegion(x, y, width, height int) {
+	SetTitleBarDragRegion(x, y, width, height)
+}
+func (w *Window) GetContentRect() (x, y, width, height int) { return GetWindowContentRect() }
 
 // Input wrappers (keyboard)
 func (w *Window) GetKeyPressed() int              { return GetKeyPressed() }
@@ -397,14 +1391,32 @@ func (w *Window) IsShiftDown() bool               { return IsShiftDown() }
 func (w *Window) IsControlDown() bool             { return IsControlDown() }
 func (w *Window) IsAltDown() bool                 { return IsAltDown() }
 
+// Key binding wrappers; see LoadKeyBindings
+func (w *Window) IsActionPressed(name string) bool { return IsActionPressed(name) }
+func (w *Window) IsActionDown(name string) bool    { return IsActionDown(name) }
+
+// GetKeyPressedEx dequeues the next pressed key with its modifiers; see GetKeyPressedEx.
+func (w *Window) GetKeyPressedEx() (key, mods int) { return GetKeyPressedEx() }
+
 // Input wrappers (mouse)
 func (w *Window) IsMouseButtonDown(btn int) bool     { return IsMouseButtonDown(btn) }
 func (w *Window) IsMouseButtonUp(btn int) bool       { return IsMouseButtonUp(btn) }
 func (w *Window) IsMouseButtonPressed(btn int) bool  { return IsMouseButtonPressed(btn) }
 func (w *Window) IsMouseButtonReleased(btn int) bool { return IsMouseButtonReleased(btn) }
+func (w *Window) GetMouseButtonsDown() int           { return GetMouseButtonsDown() }
+func (w *Window) GetMouseButtonClicks(btn int) int   { return GetMouseButtonClicks(btn) }
 func (w *Window) MouseGetPosition() (int, int)       { return GetMousePosition() }
+func (w *Window) IsCursorOnScreen() bool             { return IsCursorOnScreen() }
 func (w *Window) MouseGetX() int                     { x, _ := GetMousePosition(); return x }
 func (w *Window) MouseGetY() int                     { _, y := GetMousePosition(); return y }
+func (w *Window) MouseGetWheelMove() float64         { return GetMouseWheelMove() }
+func (w *Window) MouseGetWheelNotches() int          { return GetMouseWheelNotches() }
+func (w *Window) MouseGetPositionDIP() (float64, float64) {
+	return GetMousePositionDIP()
+}
+func (w *Window) EnableRawMouseInput(on bool)    { EnableRawMouseInput(on) }
+func (w *Window) GetRawMouseDelta() (dx, dy int) { return GetRawMouseDelta() }
+func (w *Window) SetCustomCursor(c Cursor)       { SetCustomCursor(c) }
 
 // helpers ------------------------------------------------------------------
 
@@ -0,0 +1,37 @@
+package winui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleThenActive(t *testing.T) {
+	useStub(t, &fakeNative{})
+	resetInput(t)
+	w := InitWindowHandler()
+
+	const threshold = 50 * time.Millisecond
+	idles, actives := 0, 0
+	w.OnIdle(func(time.Duration) { idles++ }, threshold)
+	w.OnActive(func() { actives++ })
+	st := w.newFrameState()
+
+	w.frame(st, nil, time.Now())
+	if idles != 0 {
+		t.Fatalf("OnIdle ran %d times before the threshold", idles)
+	}
+
+	time.Sleep(threshold + 10*time.Millisecond)
+	w.frame(st, nil, time.Now())
+	if idles != 1 || actives != 0 {
+		t.Fatalf("after the threshold: OnIdle %d, OnActive %d; want 1, 0", idles, actives)
+	}
+
+	// input ends the idle period: OnActive once, no further OnIdle
+	handleNativeInput(EventKindKey, packCode(vkA, 0), ActionDown, 0)
+	w.frame(st, nil, time.Now())
+	w.frame(st, nil, time.Now())
+	if idles != 1 || actives != 1 {
+		t.Fatalf("after input: OnIdle %d, OnActive %d; want 1, 1", idles, actives)
+	}
+}
@@ -31,6 +31,7 @@ package winui
 // NOTE: Because WinUI requires STA, ensure your main Go goroutine is locked to
 // an OS thread if you plan to host any message pumping logic there (runtime.LockOSThread()).
 // The native DLL spins its own UI thread so basic usage does not strictly require it.
+// MainLoop locks the thread and initializes COM for you.
 
 import (
 	"context"
@@ -39,6 +40,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -60,10 +62,59 @@ const (
 	EventKindResize  = 3
 	EventKindClosed  = 4
 	EventKindCreated = 5
+	// EventKindFocus reports window activation changes; Action is
+	// ActionFocusGained or ActionFocusLost.
+	EventKindFocus = 6
+	// EventKindDPIChanged reports a DPI change (e.g. moved to another
+	// monitor); Code carries the new DPI (96 = 100%).
+	EventKindDPIChanged = 7
+	// EventKindAccentColorChanged reports a change of the system accent
+	// color; query it with GetSystemAccentColor.
+	EventKindAccentColorChanged = 8
+	// EventKindMoved reports a window move; X,Y carry the new outer window
+	// origin in screen coordinates. Consecutive moves are coalesced.
+	EventKindMoved = 9
+	// EventKindSession reports the user session being locked or unlocked;
+	// Action is ActionSessionLock or ActionSessionUnlock.
+	EventKindSession = 10
+	// EventKindPower reports the machine suspending or resuming; Action is
+	// ActionPowerSuspend or ActionPowerResume.
+	EventKindPower = 11
+	// EventKindWake is posted by Window.RequestRender to end a wait for
+	// events; it carries no data.
+	EventKindWake = 12
+	// EventKindDisplayChanged reports a change of display resolution or
+	// rotation; consecutive changes are coalesced.
+	EventKindDisplayChanged = 13
+	// EventKindResizeDrag reports the user starting or finishing a resize by
+	// dragging the window border; Action is ActionResizeStart or
+	// ActionResizeEnd. Moving the window by its caption reports nothing.
+	EventKindResizeDrag = 14
 
 	ActionDown = 1
 	ActionUp   = 2
 	ActionChar = 3 // (currently only for key events if ever surfaced)
+	// ActionWheel is reported for mouse wheel events; the signed delta (multiples
+	// of WHEEL_DELTA=120 per notch) travels in the low 16 bits of the code field.
+	ActionWheel = 4
+	// ActionEnter / ActionLeave are reported for mouse events when the cursor
+	// enters or leaves the client area (code is unused).
+	ActionEnter = 5
+	ActionLeave = 6
+
+	// Actions of EventKindFocus events.
+	ActionFocusGained = 1
+	ActionFocusLost   = 2
+
+	// Actions of EventKindSession and EventKindPower events.
+	ActionSessionLock   = 1
+	ActionSessionUnlock = 2
+	ActionPowerSuspend  = 1
+	ActionPowerResume   = 2
+
+	// Actions of EventKindResizeDrag events.
+	ActionResizeStart = 1
+	ActionResizeEnd   = 2
 	// Define idxEx locally in ToggleFullscreen
 	// Add window APIs: GetWindowHandle, IsWindowFullscreen, ShowWindow/HideWindow, CloseWindow, and min/max size hint storage.
 )
@@ -85,7 +136,8 @@ type Event struct {
 type ResizeHandler func(width, height int)
 
 // InputHandler invoked for low-level immediate callbacks (distinct from polled events).
-// kind:1=key 2=mouse; action:1=down 2=up 3=char; mods bitmask (side-specific); x,y for mouse.
+// kind:1=key 2=mouse; action:1=down 2=up 3=char 4=wheel; mods bitmask (side-specific); x,y for mouse.
+// For wheel events code carries the signed raw delta.
 type InputHandler func(kind, code, action, mods, x, y int)
 
 // -----------------------------------------------------------------------------
@@ -104,11 +156,24 @@ var (
 	keyPressedOnce  = make(map[int]bool) // down edge this frame
 	keyReleasedOnce = make(map[int]bool) // up edge this frame
 	keyRepeat       = make(map[int]bool) // repeat events (down while already held)
-	keyPressQueue   []int                // ordered pressed keys
+	keyPressQueue   []keyPress           // ordered pressed keys
 	charPressQueue  []int                // unicode codepoints
 	currentMods     int                  // last observed modifiers mask
+
+	inputQueueLimit   = defaultInputQueueLimit // max entries per queue; <= 0 is unbounded
+	inputQueueDropped int                      // entries discarded by the limit
 )
 
+// defaultInputQueueLimit bounds the key and char queues so a loop that stops
+// draining them does not grow them forever.
+const defaultInputQueueLimit = 1024
+
+// keyPress is a queued key press with the modifiers held when it happened.
+type keyPress struct {
+	code int
+	mods int
+}
+
 // Modifiers bitmask (matches native GetModifiersMask mapping)
 const (
 	ModLShift   = 1
@@ -128,9 +193,11 @@ const (
 
 // Mouse buttons mapping (from native emitter)
 const (
-	MouseButtonLeft   = 1
-	MouseButtonRight  = 2
-	MouseButtonMiddle = 3
+	MouseButtonLeft    = 1
+	MouseButtonRight   = 2
+	MouseButtonMiddle  = 3
+	MouseButtonBack    = 4 // XBUTTON1, the rear thumb button
+	MouseButtonForward = 5 // XBUTTON2, the front thumb button
 )
 
 // Mouse state
@@ -139,9 +206,32 @@ var (
 	mouseDown         = make(map[int]bool)
 	mousePressedOnce  = make(map[int]bool)
 	mouseReleasedOnce = make(map[int]bool)
+	mouseClicks       = make(map[int]int) // presses per button this frame
 	mouseX, mouseY    int
+	mouseWheelDelta   int // raw wheel delta accumulated this frame
+	mouseWheelNotches int // whole notches completed this frame
+	mouseWheelRemain  int // sub-notch delta carried across frames
+	mouseRawDX        int // raw mouse motion this frame (EnableRawMouseInput)
+	mouseRawDY        int
 )
 
+// mouseInWindow is 1 while the cursor is over the client area (native hover events).
+var mouseInWindow uint32
+
+// lastInputNS is the UnixNano time of the last key or mouse event; 0 before any.
+var lastInputNS int64
+
+// lastInputTime returns the time of the last key or mouse event (zero if none).
+func lastInputTime() time.Time {
+	if ns := atomic.LoadInt64(&lastInputNS); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
+// wheelDelta is the native delta reported per wheel notch (WHEEL_DELTA).
+const wheelDelta = 120
+
 // resetTransient clears per-frame key transition maps and queues.
 func resetTransient() {
 	for k := range keyPressedOnce {
@@ -191,14 +281,23 @@ func IsKeyReleased(key int) bool {
 
 // GetKeyPressed dequeues next pressed keycode, or 0 if none.
 func GetKeyPressed() int {
+	k, _ := GetKeyPressedEx()
+	return k
+}
+
+// GetKeyPressedEx dequeues the next pressed keycode together with the
+// modifiers mask captured when the key went down; (0, 0) if none. Use it for
+// shortcuts: GetModifiers reports the current state, which may have changed
+// since the press.
+func GetKeyPressedEx() (key, mods int) {
 	keyStateMu.Lock()
 	defer keyStateMu.Unlock()
 	if len(keyPressQueue) == 0 {
-		return 0
+		return 0, 0
 	}
 	k := keyPressQueue[0]
 	keyPressQueue = keyPressQueue[1:]
-	return k
+	return k.code, k.mods
 }
 
 // GetCharPressed dequeues next character codepoint (currently same as key code) or 0.
@@ -213,6 +312,36 @@ func GetCharPressed() int {
 	return c
 }
 
+// SetInputQueueLimit caps the key and char queues (GetKeyPressed,
+// GetCharPressed) at n entries each; once full, the oldest entries are dropped
+// to make room. n <= 0 removes the cap. The default is 1024.
+func SetInputQueueLimit(n int) {
+	keyStateMu.Lock()
+	inputQueueLimit = n
+	keyPressQueue = trimInputQueue(keyPressQueue)
+	charPressQueue = trimInputQueue(charPressQueue)
+	keyStateMu.Unlock()
+}
+
+// GetInputQueueDropped returns how many queued key and char entries have been
+// dropped by the queue limit since startup.
+func GetInputQueueDropped() int {
+	keyStateMu.Lock()
+	defer keyStateMu.Unlock()
+	return inputQueueDropped
+}
+
+// trimInputQueue drops the oldest entries of q beyond inputQueueLimit and
+// counts them. Caller holds keyStateMu.
+func trimInputQueue[T any](q []T) []T {
+	n := len(q) - inputQueueLimit
+	if inputQueueLimit <= 0 || n <= 0 {
+		return q
+	}
+	inputQueueDropped += n
+	return q[n:]
+}
+
 // ResetKeyTransitions clears per-frame pressed/released/repeat/queues for both
 // keyboard and mouse. Call once per frame.
 func ResetKeyTransitions() {
@@ -224,13 +353,38 @@ func ResetKeyTransitions() {
 	for k := range mouseReleasedOnce {
 		delete(mouseReleasedOnce, k)
 	}
+	clear(mouseClicks)
+	mouseWheelDelta = 0
+	mouseWheelNotches = 0 // the sub-notch remainder carries over
+	mouseRawDX, mouseRawDY = 0, 0
 	mouseStateMu.Unlock()
 
 	// Clear key transitions and queues
 	keyStateMu.Lock()
 	resetTransient()
 	keyStateMu.Unlock()
+	resetWindowFlags()
+
+	// Next frame begins: deliver any recorded input scheduled for it.
+	atomic.AddUint64(&frameCounter, 1)
+	advancePlayback()
+}
+
+// resetWindowFlags clears the per-frame resize and move flags.
+func resetWindowFlags() {
 	atomic.StoreUint32(&windowResizedFlag, 0)
+	atomic.StoreUint32(&windowUserResized, 0)
+	atomic.StoreUint32(&windowMovedFlag, 0)
+}
+
+// markWindowResized records a native resize for this frame. The native size
+// callback runs while SetWindowPos is delivering WM_SIZE, so a resize seen
+// during a SetWindowSize call is the programmatic one.
+func markWindowResized() {
+	atomic.StoreUint32(&windowResizedFlag, 1)
+	if programmaticSize.Load() == 0 {
+		atomic.StoreUint32(&windowUserResized, 1)
+	}
 }
 
 // helper: get or find the native HWND by window title or foreground window
@@ -287,9 +441,35 @@ func IsMouseButtonReleased(button int) bool {
 	mouseStateMu.Unlock()
 	return v
 }
+
+// GetMouseButtonsDown returns the held buttons as a bitmask: bit n-1 is set
+// while button n is down (bit 0 left, bit 1 right, bit 2 middle, bits 3 and 4
+// back and forward).
+func GetMouseButtonsDown() int {
+	mouseStateMu.Lock()
+	defer mouseStateMu.Unlock()
+	mask := 0
+	for b, down := range mouseDown {
+		if down && b >= 1 && b <= 31 {
+			mask |= 1 << (b - 1)
+		}
+	}
+	return mask
+}
+
+// GetMouseButtonClicks returns how many times button was pressed this frame.
+// IsMouseButtonPressed only reports whether it was; the count tells a fast
+// double click apart from a single one when both land in one frame.
+func GetMouseButtonClicks(button int) int {
+	mouseStateMu.Lock()
+	n := mouseClicks[button]
+	mouseStateMu.Unlock()
+	return n
+}
 func GetMouseX() int { mouseStateMu.Lock(); x := mouseX; mouseStateMu.Unlock(); return x }
 func GetMouseY() int { mouseStateMu.Lock(); y := mouseY; mouseStateMu.Unlock(); return y }
 
+// GetMousePosition returns the last mouse position in client-area physical pixels.
 func GetMousePosition() (int, int) {
 	mouseStateMu.Lock()
 	x, y := mouseX, mouseY
@@ -297,6 +477,77 @@ func GetMousePosition() (int, int) {
 	return x, y
 }
 
+// GetMousePositionDIP returns the last mouse position in device-independent
+// pixels (DIPs), the unit of WinUI layout and control bounds. It divides the
+// pixel position by the DPI scale current at the time of the call, so a scale
+// change between the event and the call is not accounted for.
+func GetMousePositionDIP() (float64, float64) {
+	x, y := GetMousePosition()
+	sx, sy := GetWindowScaleDPI()
+	if sx <= 0 {
+		sx = 1
+	}
+	if sy <= 0 {
+		sy = 1
+	}
+	return float64(x) / sx, float64(y) / sy
+}
+
+// GetMouseWheelMove returns the wheel movement accumulated this frame in
+// notches (positive = away from the user). The value is visible for exactly
+// one frame and cleared by ResetKeyTransitions.
+func GetMouseWheelMove() float64 {
+	mouseStateMu.Lock()
+	d := mouseWheelDelta
+	mouseStateMu.Unlock()
+	return float64(d) / wheelDelta
+}
+
+// IsCursorOnScreen returns true if the cursor is currently within the client
+// area of the window. It queries user32 directly, so it is accurate even
+// between frames and for DLLs without hover events.
+func IsCursorOnScreen() bool {
+	h := getHWND()
+	if h == 0 || procGetCursorPos.Find() != nil || procGetClientRect.Find() != nil || procClientToScreen.Find() != nil {
+		return false
+	}
+	var pt, origin point
+	if r, _, _ := procGetCursorPos.Call(uintptr(unsafe.Pointer(&pt))); r == 0 {
+		return false
+	}
+	var rc rect
+	if r, _, _ := procGetClientRect.Call(h, uintptr(unsafe.Pointer(&rc))); r == 0 {
+		return false
+	}
+	procClientToScreen.Call(h, uintptr(unsafe.Pointer(&origin)))
+	x, y := pt.X-origin.X, pt.Y-origin.Y
+	return x >= rc.Left && x < rc.Right && y >= rc.Top && y < rc.Bottom
+}
+
+// isMouseInWindow reports the hover state tracked from native enter/leave events.
+func isMouseInWindow() bool { return atomic.LoadUint32(&mouseInWindow) != 0 }
+
+// GetMouseWheelNotches returns the whole wheel notches scrolled this frame
+// (positive = away from the user). Partial deltas from high-resolution wheels
+// and touchpads accumulate across frames until they complete a notch, which
+// suits discrete steps such as zoom levels.
+func GetMouseWheelNotches() int {
+	mouseStateMu.Lock()
+	n := mouseWheelNotches
+	mouseStateMu.Unlock()
+	return n
+}
+
+// ResetMouseWheel clears the accumulated wheel delta, notches and sub-notch
+// remainder without touching the other per-frame transitions.
+func ResetMouseWheel() {
+	mouseStateMu.Lock()
+	mouseWheelDelta = 0
+	mouseWheelNotches = 0
+	mouseWheelRemain = 0
+	mouseStateMu.Unlock()
+}
+
 // SetMousePosition sets the global cursor position (screen coordinates).
 func SetMousePosition(x, y int) {
 	// Best-effort; if unavailable, silently ignore.
@@ -311,18 +562,18 @@ var (
 	mod     *windows.DLL
 
 	// Proc pointers
-	pInitUI, pShutdownUI                                               *windows.Proc
-	pCreateWindow, pCreateTextInput                                    *windows.Proc
-	pGetMainWindow, pWindowExists, pIsWindowReady, pWaitForWindowReady *windows.Proc
-	pSetWindowTitle, pGetWindowSize                                    *windows.Proc
-	pRegisterResizeCallback                                            *windows.Proc
-	pRegisterInputCallback                                             *windows.Proc
-	pSetWindowBackgroundColor                                          *windows.Proc
-	pPollEvents                                                        *windows.Proc
-	pRegisterCloseCallback                                             *windows.Proc
-	pBeginShutdownAsync                                                *windows.Proc
-	pGetRuntimeState                                                   *windows.Proc
-	pSetWindowMinMax                                                   *windows.Proc
+	pInitUI, pShutdownUI                                               *nativeProc
+	pCreateWindow, pCreateTextInput                                    *nativeProc
+	pGetMainWindow, pWindowExists, pIsWindowReady, pWaitForWindowReady *nativeProc
+	pSetWindowTitle, pGetWindowSize                                    *nativeProc
+	pRegisterResizeCallback                                            *nativeProc
+	pRegisterInputCallback                                             *nativeProc
+	pSetWindowBackgroundColor                                          *nativeProc
+	pPollEvents                                                        *nativeProc
+	pRegisterCloseCallback                                             *nativeProc
+	pBeginShutdownAsync                                                *nativeProc
+	pGetRuntimeState                                                   *nativeProc
+	pSetWindowMinMax                                                   *nativeProc
 
 	resizeHandlerMu sync.RWMutex
 	resizeHandler   ResizeHandler
@@ -345,6 +596,7 @@ var (
 	timeStart     time.Time
 	targetFPS     int32 = 60
 	lastFrameNS   int64 // nanoseconds for last completed frame
+	frameTimeMode int32 // FrameTimeMeasured or FrameTimeFixed
 )
 
 // window state tracking
@@ -353,10 +605,15 @@ var (
 	cachedHWND        uintptr
 	lastWindowTitle   string
 	windowResizedFlag uint32
+	windowUserResized uint32       // a resize this frame was not made by SetWindowSize
+	programmaticSize  atomic.Int32 // SetWindowSize calls in progress
+	windowMovedFlag   uint32
+	windowMovedPos    atomic.Uint64 // packed x (low 32) / y (high 32) of the last move
 
 	savedStyle   uintptr
 	savedExStyle uintptr
 	savedRect    rect
+	savedDPI     uint32 // window DPI when savedRect was taken
 )
 
 // user32 imports for text translation and cursor control
@@ -385,6 +642,14 @@ var (
 	procGetWindowLongPtrW = user32.NewProc("GetWindowLongPtrW")
 	procSetWindowLongPtrW = user32.NewProc("SetWindowLongPtrW")
 	procSetLayeredAttr    = user32.NewProc("SetLayeredWindowAttributes")
+	procGetLayeredAttr    = user32.NewProc("GetLayeredWindowAttributes")
+	procClientToScreen    = user32.NewProc("ClientToScreen")
+	procScreenToClient    = user32.NewProc("ScreenToClient")
+	procGetCursorPos      = user32.NewProc("GetCursorPos")
+	procAttachThreadInput = user32.NewProc("AttachThreadInput")
+	procBringWindowToTop  = user32.NewProc("BringWindowToTop")
+	procGetLastInputInfo  = user32.NewProc("GetLastInputInfo")
+	procGetTickCount      = kernel32.NewProc("GetTickCount")
 )
 
 // RECT structure for GetWindowRect
@@ -392,6 +657,19 @@ type rect struct {
 	Left, Top, Right, Bottom int32
 }
 
+// Rect is a rectangle in pixels: origin X,Y and size W,H.
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Empty reports whether r has no area.
+func (r Rect) Empty() bool { return r.W <= 0 || r.H <= 0 }
+
+// Contains reports whether the point x,y lies inside r.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
+
 // window constants
 const (
 	GWL_STYLE   = -16
@@ -408,25 +686,34 @@ const (
 
 	WS_OVERLAPPEDWINDOW = WS_OVERLAPPED | WS_CAPTION | WS_SYSMENU | WS_THICKFRAME | WS_MINIMIZEBOX | WS_MAXIMIZEBOX
 
-	WS_EX_LAYERED = 0x00080000
+	WS_EX_LAYERED     = 0x00080000
+	WS_EX_TRANSPARENT = 0x00000020
+	WS_EX_NOACTIVATE  = 0x08000000
+	WS_EX_TOOLWINDOW  = 0x00000080
+	WS_EX_APPWINDOW   = 0x00040000
 
-	SW_SHOW     = 5
-	SW_HIDE     = 0
-	SW_MINIMIZE = 6
-	SW_RESTORE  = 9
-	SW_MAXIMIZE = 3
+	SW_SHOW           = 5
+	SW_SHOWNOACTIVATE = 4
+	SW_SHOWNA         = 8
+	SW_HIDE           = 0
+	SW_MINIMIZE       = 6
+	SW_RESTORE        = 9
+	SW_MAXIMIZE       = 3
 
 	SWP_NOSIZE         = 0x0001
 	SWP_NOMOVE         = 0x0002
 	SWP_NOZORDER       = 0x0004
+	SWP_NOACTIVATE     = 0x0010
 	SWP_NOOWNERZORDER  = 0x0200
 	SWP_FRAMECHANGED   = 0x0020
 	SWP_NOSENDCHANGING = 0x0400
 
-	SM_CXSCREEN = 0
-	SM_CYSCREEN = 1
+	SM_CXSCREEN  = 0
+	SM_CYSCREEN  = 1
+	SM_CYCAPTION = 4
 
-	LWA_ALPHA = 0x00000002
+	LWA_COLORKEY = 0x00000001
+	LWA_ALPHA    = 0x00000002
 )
 
 // Virtual-key codes used for modifier key state population
@@ -493,6 +780,14 @@ func translateVKToRunes(vk, mods int) []rune {
 // to the DLL search path (SetDllDirectory) for the duration of load.
 func Load(dllDirs ...string) error {
 	dllOnce.Do(func() {
+		// A registered stub replaces the DLL entirely (headless/test mode).
+		if b := currentNativeStub(); b != nil {
+			logf(LogInfo, "using native stub backend; WinUI3Native.dll is not loaded")
+			stub := func(name string) *nativeProc { return &nativeProc{name: name, backend: b} }
+			bindProcs(stub, stub)
+			return
+		}
+
 		// Candidate directories: user-provided, exe dir, cwd, bin/x64/{Debug,Release}
 		var cands []string
 		for _, d := range dllDirs {
@@ -514,48 +809,47 @@ func Load(dllDirs ...string) error {
 		for _, dir := range cands {
 			_ = windows.SetDllDirectory(dir)
 			if m, e := windows.LoadDLL("WinUI3Native.dll"); e == nil {
+				logf(LogInfo, "loaded WinUI3Native.dll from %s", dir)
 				mod = m
 				loaded = true
 				break
 			} else {
+				logf(LogDebug, "WinUI3Native.dll not loaded from %s: %v", dir, e)
 				lastErr = e
 			}
 		}
 		if !loaded {
 			if m, e := windows.LoadDLL("WinUI3Native.dll"); e == nil {
+				logf(LogInfo, "loaded WinUI3Native.dll from the default search path")
 				mod = m
 			} else {
 				dllErr = fmt.Errorf("load WinUI3Native.dll: %w", lastErr)
+				logf(LogError, "%v", dllErr)
 				return
 			}
 		}
 
 		// Resolve all procedures; fail fast if any are missing.
-		must := func(name string) *windows.Proc {
+		must := func(name string) *nativeProc {
 			p, err := mod.FindProc(name)
 			if err != nil {
 				dllErr = fmt.Errorf("missing export %s: %w", name, err)
+				logf(LogError, "%v", dllErr)
+				return nil
+			}
+			return &nativeProc{name: name, proc: p}
+		}
+		// Newer exports are optional so an older DLL still loads; the
+		// corresponding wrappers degrade to no-ops.
+		opt := func(name string) *nativeProc {
+			p, err := mod.FindProc(name)
+			if err != nil {
+				logf(LogDebug, "optional export %s not found; dependent functions are no-ops", name)
+				return nil
 			}
-			return p
+			return &nativeProc{name: name, proc: p}
 		}
-		pInitUI = must("InitUI")
-		pShutdownUI = must("ShutdownUI")
-		pCreateWindow = must("create_window")
-		pCreateTextInput = must("create_text_input")
-		pGetMainWindow = must("get_main_window")
-		pWindowExists = must("window_exists")
-		pIsWindowReady = must("is_window_ready")
-		pWaitForWindowReady = must("wait_for_window_ready")
-		pSetWindowTitle = must("set_window_title")
-		pGetWindowSize = must("get_window_size")
-		pRegisterResizeCallback = must("register_resize_callback")
-		pRegisterInputCallback = must("register_input_callback")
-		pSetWindowBackgroundColor = must("set_window_background_color")
-		pPollEvents = must("winui_poll_events")
-		pRegisterCloseCallback = must("register_close_callback")
-		pBeginShutdownAsync = must("begin_shutdown_async")
-		pGetRuntimeState = must("get_runtime_state")
-		pSetWindowMinMax = must("set_window_min_max")
+		bindProcs(must, opt)
 	})
 	if dllErr != nil {
 		return dllErr
@@ -564,16 +858,58 @@ func Load(dllDirs ...string) error {
 	return Init()
 }
 
+// bindProcs assigns every native export used by the package. must is used for
+// the core exports every DLL provides, opt for newer ones that may be absent.
+func bindProcs(must, opt func(string) *nativeProc) {
+	pInitUI = must("InitUI")
+	pShutdownUI = must("ShutdownUI")
+	pCreateWindow = must("create_window")
+	pCreateTextInput = must("create_text_input")
+	pGetMainWindow = must("get_main_window")
+	pWindowExists = must("window_exists")
+	pIsWindowReady = must("is_window_ready")
+	pWaitForWindowReady = must("wait_for_window_ready")
+	pSetWindowTitle = must("set_window_title")
+	pGetWindowSize = must("get_window_size")
+	pRegisterResizeCallback = must("register_resize_callback")
+	pRegisterInputCallback = must("register_input_callback")
+	pSetWindowBackgroundColor = must("set_window_background_color")
+	pPollEvents = must("winui_poll_events")
+	pRegisterCloseCallback = must("register_close_callback")
+	pBeginShutdownAsync = must("begin_shutdown_async")
+	pGetRuntimeState = must("get_runtime_state")
+	pSetWindowMinMax = must("set_window_min_max")
+
+	resolveControlProcs(opt)
+	pSetCustomTitleBar = opt("set_custom_title_bar")
+	pSetTitleBarDragRegion = opt("set_title_bar_drag_region")
+	pGetTitleBarHeight = opt("get_title_bar_height")
+	pSetMinimizeToTray = opt("set_minimize_to_tray")
+	pSetEventQueueCapacity = opt("winui_set_event_queue_capacity")
+	pSetEventOverflowPolicy = opt("winui_set_event_overflow_policy")
+	pGetDroppedEventCount = opt("winui_get_dropped_event_count")
+	pWaitForEvents = opt("winui_wait_for_events")
+	pPostWakeEvent = opt("winui_post_wake_event")
+	pSetTaskbarProgress = opt("set_taskbar_progress")
+	pSetTaskbarProgressState = opt("set_taskbar_progress_state")
+	pSetInitialWindowPosition = opt("set_initial_window_position")
+	pSetRawMouseInput = opt("set_raw_mouse_input")
+	pGetRawMouseDelta = opt("get_raw_mouse_delta")
+	pSetCustomCursor = opt("set_custom_cursor")
+}
+
 // Init initializes the WinUI runtime (bootstrap + UI thread).
 func Init() error {
 	if atomic.LoadUint32(&uiInitialized) == 1 {
 		return nil
 	}
 	if pInitUI == nil {
+		logf(LogError, "Init called before the DLL was loaded")
 		return errors.New("winui: DLL not loaded")
 	}
 	r1, _, _ := pInitUI.Call()
 	if hr := HRESULT(r1); !hr.Succeeded() {
+		logf(LogError, "InitUI failed: %s", hr)
 		return fmt.Errorf("InitUI failed: %s", hr)
 	}
 	atomic.StoreUint32(&uiInitialized, 1)
@@ -585,8 +921,13 @@ func Init() error {
 	return nil
 }
 
+// shutdownByAPI records that shutdown was requested from Go rather than by
+// the user closing the window; see Window.CloseReason.
+var shutdownByAPI atomic.Bool
+
 // Shutdown releases the runtime.
 func Shutdown() {
+	shutdownByAPI.Store(true)
 	if pShutdownUI != nil {
 		pShutdownUI.Call()
 	}
@@ -595,6 +936,7 @@ func Shutdown() {
 // BeginShutdownAsync starts native shutdown on a detached thread (idempotent).
 // Use this when you need to request shutdown without blocking caller.
 func BeginShutdownAsync() {
+	shutdownByAPI.Store(true)
 	if pBeginShutdownAsync != nil {
 		pBeginShutdownAsync.Call()
 	}
@@ -618,6 +960,7 @@ func CreateWindow(width, height int, title string) Handle {
 	lastWindowTitle = title
 	cachedHWND = 0
 	hwndMu.Unlock()
+	atomic.StoreUint32(&cachedDPI, 0) // belonged to the previous window
 	return Handle(r)
 }
 
@@ -694,6 +1037,7 @@ func CreateWindowAndWait(width, height int, title string, timeout time.Duration)
 			return 0, fmt.Errorf("window ready but handle unavailable")
 		}
 	}
+	applyPendingDWM()
 	return h, nil
 }
 
@@ -807,10 +1151,41 @@ func SetTargetFPS(fps int) {
 	atomic.StoreInt32(&targetFPS, int32(fps))
 }
 
-// GetFrameTime returns seconds elapsed for the last completed frame.
+// frameBudget returns the frame duration implied by the target FPS.
+func frameBudget() time.Duration {
+	fps := atomic.LoadInt32(&targetFPS)
+	if fps <= 0 {
+		fps = 60
+	}
+	return time.Duration(math.Round(1e9 / float64(fps)))
+}
+
+// Frame time modes for SetFrameTimeMode.
+const (
+	FrameTimeMeasured = 0 // duration of the last completed frame (default)
+	FrameTimeFixed    = 1 // exactly 1/targetFPS, whatever the real timing
+)
+
+// SetFrameTimeMode selects what GetFrameTime reports: FrameTimeMeasured
+// (default) or FrameTimeFixed for deterministic stepping, e.g. replayable
+// animations. Fixed mode does not change pacing; a loaded system then runs
+// the simulation slower than real time instead of in larger steps. GetFPS and
+// the averages always use measured times.
+func SetFrameTimeMode(mode int) {
+	if mode != FrameTimeFixed {
+		mode = FrameTimeMeasured
+	}
+	atomic.StoreInt32(&frameTimeMode, int32(mode))
+}
+
+// GetFrameTime returns seconds elapsed for the last completed frame, or
+// 1/targetFPS in FrameTimeFixed mode. During the first frame no frame has
+// completed yet and it returns 1/targetFPS, not the (unknown) duration of the
+// frame in progress; a slow first frame is only reported on the next one. Use
+// GetTimeScaled to bound the step.
 func GetFrameTime() float64 {
 	ns := atomic.LoadInt64(&lastFrameNS)
-	if ns <= 0 {
+	if ns <= 0 || atomic.LoadInt32(&frameTimeMode) == FrameTimeFixed {
 		// Derive from target FPS if no frame has completed yet
 		fps := atomic.LoadInt32(&targetFPS)
 		if fps <= 0 {
@@ -821,6 +1196,17 @@ func GetFrameTime() float64 {
 	return float64(ns) / 1e9
 }
 
+// GetTimeScaled returns GetFrameTime clamped to maxDelta seconds, so a stall
+// (a breakpoint, a dragged window, a slow first real frame) produces one
+// bounded simulation step instead of a jump. maxDelta <= 0 disables clamping.
+func GetTimeScaled(maxDelta float64) float64 {
+	dt := GetFrameTime()
+	if maxDelta > 0 && dt > maxDelta {
+		return maxDelta
+	}
+	return dt
+}
+
 // GetTime returns seconds elapsed since Init() completed.
 func GetTime() float64 {
 	if (timeStart == time.Time{}) {
@@ -854,6 +1240,58 @@ func GetFPS() int {
 	return v
 }
 
+// frameHistorySize is the number of frames averaged by GetFPSAverage.
+const frameHistorySize = 60
+
+// rolling window of recent frame durations (ns)
+var (
+	frameHistMu  sync.Mutex
+	frameHist    [frameHistorySize]int64
+	frameHistPos int
+	frameHistLen int
+	frameHistSum int64
+)
+
+// recordFrameTime stores the duration of a completed frame for GetFrameTime,
+// GetFPS and the rolling averages.
+func recordFrameTime(ns int64) {
+	atomic.StoreInt64(&lastFrameNS, ns)
+	frameHistMu.Lock()
+	frameHistSum += ns - frameHist[frameHistPos]
+	frameHist[frameHistPos] = ns
+	frameHistPos = (frameHistPos + 1) % frameHistorySize
+	if frameHistLen < frameHistorySize {
+		frameHistLen++
+	}
+	frameHistMu.Unlock()
+}
+
+// GetFrameTimeAverage returns the mean measured frame time in seconds over the
+// last frameHistorySize frames. Falls back to 1/targetFPS before any frame completed.
+func GetFrameTimeAverage() float64 {
+	frameHistMu.Lock()
+	n, sum := frameHistLen, frameHistSum
+	frameHistMu.Unlock()
+	if n == 0 || sum <= 0 {
+		return frameBudget().Seconds()
+	}
+	return float64(sum) / float64(n) / 1e9
+}
+
+// GetFPSAverage returns the FPS averaged over the last frameHistorySize frames
+// (rounded). Unlike GetFPS it is stable enough for on-screen counters.
+func GetFPSAverage() int {
+	dt := GetFrameTimeAverage()
+	if dt <= 0 {
+		return GetFPS()
+	}
+	v := int(math.Round(1.0 / dt))
+	if v < 1 {
+		v = 1
+	}
+	return v
+}
+
 // RunPacedLoop runs a simple loop paced at the current target FPS (default 60).
 // Each iteration polls events (with transitions reset) and invokes onTick.
 // The loop exits when the window should close or when onTick returns false.
@@ -874,18 +1312,14 @@ func RunPacedLoop(onTick func([]Event) bool) {
 		}
 
 		// Pace to target FPS
-		fps := atomic.LoadInt32(&targetFPS)
-		if fps <= 0 {
-			fps = 60
-		}
-		desiredNS := int64(math.Round(1e9 / float64(fps)))
+		desiredNS := frameBudget().Nanoseconds()
 		workNS := time.Since(frameStart).Nanoseconds()
 		sleepNS := desiredNS - workNS
 		if sleepNS > 0 {
-			time.Sleep(time.Duration(sleepNS))
+			paceSleep(time.Duration(sleepNS))
 		}
 		// Record full frame duration (work + sleep)
-		atomic.StoreInt64(&lastFrameNS, time.Since(frameStart).Nanoseconds())
+		recordFrameTime(time.Since(frameStart).Nanoseconds())
 	}
 }
 
@@ -937,6 +1371,7 @@ func SetWindowTitle(title string) {
 	lastWindowTitle = title
 	cachedHWND = 0
 	hwndMu.Unlock()
+	atomic.StoreUint32(&cachedDPI, 0) // re-read from whichever window resolves
 }
 
 // GetWindowSize returns width/height.
@@ -975,7 +1410,22 @@ func (c Color) ARGB() (a, r, g, b uint8) {
 }
 
 // SetWindowBackgroundColor sets window background using a Color (0xAARRGGBB).
+// While a backdrop material is active (SetWindowBackdrop) only translucent
+// colors are painted, acting as a tint; opaque colors are remembered and
+// applied once the backdrop is removed.
 func SetWindowBackgroundColor(c Color) {
+	backdropMu.Lock()
+	lastBackground, lastBackgroundSet = c, true
+	active := backdropActive
+	backdropMu.Unlock()
+	if a, _, _, _ := c.ARGB(); active && a == 255 {
+		return
+	}
+	applyBackgroundColor(c)
+}
+
+// applyBackgroundColor paints the root background without touching backdrop state.
+func applyBackgroundColor(c Color) {
 	if pSetWindowBackgroundColor == nil {
 		return
 	}
@@ -998,27 +1448,7 @@ func RegisterResizeHandler(h ResizeHandler, debounce time.Duration) {
 	// Base immediate handler target (may be wrapped for debounce).
 	target := h
 	if debounce > 0 {
-		if debounce <= 0 {
-			debounce = 150 * time.Millisecond // guard
-		}
-		var mu sync.Mutex
-		var timer *time.Timer
-		var lastW, lastH int
-		immediate := h
-		target = func(w, hgt int) {
-			mu.Lock()
-			lastW, lastH = w, hgt
-			if timer != nil {
-				timer.Stop()
-			}
-			timer = time.AfterFunc(debounce, func() {
-				mu.Lock()
-				lw, lh := lastW, lastH
-				mu.Unlock()
-				immediate(lw, lh)
-			})
-			mu.Unlock()
-		}
+		target = debounceResize(h, debounce)
 	}
 
 	resizeHandlerMu.Lock()
@@ -1036,7 +1466,8 @@ func RegisterResizeHandler(h ResizeHandler, debounce time.Duration) {
 			hf := math.Float64frombits(uint64(hBits))
 			wi := int(math.Round(wf))
 			hi := int(math.Round(hf))
-			atomic.StoreUint32(&windowResizedFlag, 1)
+			markWindowResized()
+			atomic.StoreUint32(&cachedDPI, 0) // may follow a DPI change
 			resizeHandlerMu.RLock()
 			rh := resizeHandler
 			resizeHandlerMu.RUnlock()
@@ -1049,6 +1480,28 @@ func RegisterResizeHandler(h ResizeHandler, debounce time.Duration) {
 	pRegisterResizeCallback.Call(resizeCallbackPtr)
 }
 
+// debounceResize wraps h so that it runs once no further call has arrived for
+// d, with the last size seen. h runs on a timer goroutine.
+func debounceResize(h ResizeHandler, d time.Duration) ResizeHandler {
+	var mu sync.Mutex
+	var timer *time.Timer
+	var lastW, lastH int
+	return func(w, hgt int) {
+		mu.Lock()
+		lastW, lastH = w, hgt
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(d, func() {
+			mu.Lock()
+			lw, lh := lastW, lastH
+			mu.Unlock()
+			h(lw, lh)
+		})
+		mu.Unlock()
+	}
+}
+
 // DefaultResizeDebounce defines the default debounce used by OnResize.
 // Adjust if you want a snappier or lazier resize callback in simple apps.
 var DefaultResizeDebounce = 200 * time.Millisecond
@@ -1067,13 +1520,14 @@ func OnResizeImmediate(h ResizeHandler) { RegisterResizeHandler(h, 0) }
 // and size queries responsive without extra user code.
 func ensureResizeCallbackRegistered() {
 	if pRegisterResizeCallback == nil {
+		logf(LogWarn, "register_resize_callback unavailable; resize tracking disabled")
 		return
 	}
 	if resizeCallbackPtr == 0 {
 		resizeCallbackPtr = syscall.NewCallback(func(wBits, hBits uintptr) uintptr {
 			wf := math.Float64frombits(uint64(wBits))
 			hf := math.Float64frombits(uint64(hBits))
-			atomic.StoreUint32(&windowResizedFlag, 1)
+			markWindowResized()
 			// If a user handler is present, invoke it
 			resizeHandlerMu.RLock()
 			rh := resizeHandler
@@ -1095,75 +1549,145 @@ func RegisterInputHandler(h InputHandler) {
 	defer inputHandlerMu.Unlock()
 	inputHandler = h
 	if pRegisterInputCallback == nil {
+		logf(LogWarn, "input handler stored but register_input_callback is unavailable")
 		return
 	}
 	if inputCallbackPtr == 0 {
-		// New packed native signature: (int kind, int codeWithMods, int action, uint64 packedXY)
-		// codeWithMods: low 16 bits = code (vk or mouse button), high 16 bits = mods.
-		// packedXY: low 32 bits = x, high 32 bits = y (unsigned); key events have x=y=0.
-		inputCallbackPtr = syscall.NewCallback(func(kind, codeWithMods, action, packedXY uintptr) uintptr {
-			ik := int(kind)
-			cwm := uint32(codeWithMods)
-			code := int(cwm & 0xFFFF)
-			mods := int((cwm >> 16) & 0xFFFF)
-			ac := int(action)
-			pxy := uint64(packedXY)
-			x := int(uint32(pxy & 0xFFFFFFFF))
-			y := int(uint32(pxy >> 32))
-
-			switch ik {
-			case EventKindKey:
-				keyStateMu.Lock()
-				switch ac {
-				case ActionDown:
-					if !keyDown[code] {
-						keyPressedOnce[code] = true
-						keyPressQueue = append(keyPressQueue, code)
-						keyDown[code] = true
-						for _, r := range translateVKToRunes(code, mods) {
-							charPressQueue = append(charPressQueue, int(r))
-						}
-					} else {
-						keyRepeat[code] = true
-					}
-				case ActionUp:
-					if keyDown[code] {
-						keyReleasedOnce[code] = true
-						delete(keyDown, code)
-					}
-				}
-				currentMods = mods
-				keyStateMu.Unlock()
-			case EventKindMouse:
-				mouseStateMu.Lock()
-				mouseX, mouseY = x, y
-				switch ac {
-				case ActionDown:
-					if !mouseDown[code] {
-						mousePressedOnce[code] = true
-						mouseDown[code] = true
-					}
-				case ActionUp:
-					if mouseDown[code] {
-						mouseReleasedOnce[code] = true
-						delete(mouseDown, code)
-					}
+		inputCallbackPtr = syscall.NewCallback(dispatchInput)
+	}
+	pRegisterInputCallback.Call(inputCallbackPtr)
+	logf(LogDebug, "input callback registered")
+}
+
+// dispatchInput is the native input callback target shared by
+// RegisterInputHandler and ensureInputCallbackRegistered. It only converts the
+// raw callback arguments; all decoding and state updates live in handleNativeInput.
+func dispatchInput(kind, codeWithMods, action, packedXY uintptr) uintptr {
+	k, cwm, a := int(int32(kind)), int(int32(codeWithMods)), int(int32(action))
+	recordInput(k, cwm, a, uint64(packedXY))
+	handleNativeInput(k, cwm, a, uint64(packedXY))
+	return 0
+}
+
+// clearInputOnFocusLoss (1 = on, the default) releases held keys and buttons
+// when the window loses focus; see SetClearInputOnFocusLoss.
+var clearInputOnFocusLoss uint32 = 1
+
+// SetClearInputOnFocusLoss controls what happens to held keys and mouse
+// buttons when the window loses focus. The matching up events then go to the
+// newly focused window, so without clearing they would stay "down" (the stuck
+// key after Alt+Tab). When on (the default), everything held is released and
+// reported by IsKeyReleased / IsMouseButtonReleased for the frame.
+func SetClearInputOnFocusLoss(on bool) {
+	var v uint32
+	if on {
+		v = 1
+	}
+	atomic.StoreUint32(&clearInputOnFocusLoss, v)
+}
+
+// releaseHeldInput releases every held key and mouse button with release
+// edges, as if their up events had arrived, and clears the modifiers.
+func releaseHeldInput() {
+	mouseStateMu.Lock()
+	for b := range mouseDown {
+		mouseReleasedOnce[b] = true
+		delete(mouseDown, b)
+	}
+	mouseStateMu.Unlock()
+	keyStateMu.Lock()
+	for k := range keyDown {
+		keyReleasedOnce[k] = true
+		delete(keyDown, k)
+	}
+	currentMods = 0
+	keyStateMu.Unlock()
+}
+
+// unpackInput splits the packed native input arguments.
+// codeWithMods: low 16 bits = code (vk, mouse button or wheel delta), high 16 bits = mods.
+// packedXY: low 32 bits = x, high 32 bits = y (unsigned); key events have x=y=0.
+func unpackInput(codeWithMods int, packedXY uint64) (code, mods, x, y int) {
+	cwm := uint32(codeWithMods)
+	code = int(cwm & 0xFFFF)
+	mods = int((cwm >> 16) & 0xFFFF)
+	x = int(uint32(packedXY & 0xFFFFFFFF))
+	y = int(uint32(packedXY >> 32))
+	return
+}
+
+// handleNativeInput decodes one native input event, updates the keyboard/mouse
+// state (edge detection, repeat, char translation, wheel) and then forwards to
+// the user InputHandler. It has no dependency on the DLL so the whole input
+// pipeline can be exercised by feeding synthetic events.
+func handleNativeInput(kind, codeWithMods, action int, packedXY uint64) {
+	code, mods, x, y := unpackInput(codeWithMods, packedXY)
+	if kind == EventKindKey || kind == EventKindMouse {
+		atomic.StoreInt64(&lastInputNS, time.Now().UnixNano())
+	}
+
+	switch kind {
+	case EventKindKey:
+		keyStateMu.Lock()
+		switch action {
+		case ActionDown:
+			if !keyDown[code] {
+				keyPressedOnce[code] = true
+				keyPressQueue = trimInputQueue(append(keyPressQueue, keyPress{code: code, mods: mods}))
+				keyDown[code] = true
+				for _, r := range translateVKToRunes(code, mods) {
+					charPressQueue = trimInputQueue(append(charPressQueue, int(r)))
 				}
-				mouseStateMu.Unlock()
-				keyStateMu.Lock()
-				currentMods = mods
-				keyStateMu.Unlock()
+			} else {
+				keyRepeat[code] = true
 			}
-			inputHandlerMu.RLock()
-			ih := inputHandler
-			inputHandlerMu.RUnlock()
-			if ih != nil {
-				ih(ik, code, ac, mods, x, y)
+		case ActionUp:
+			if keyDown[code] {
+				keyReleasedOnce[code] = true
+				delete(keyDown, code)
 			}
-			return 0
-		})
+		}
+		currentMods = mods
+		keyStateMu.Unlock()
+	case EventKindMouse:
+		mouseStateMu.Lock()
+		mouseX, mouseY = x, y
+		switch action {
+		case ActionDown:
+			if !mouseDown[code] {
+				mousePressedOnce[code] = true
+				mouseDown[code] = true
+				mouseClicks[code]++
+			}
+		case ActionUp:
+			if mouseDown[code] {
+				mouseReleasedOnce[code] = true
+				delete(mouseDown, code)
+			}
+		case ActionWheel:
+			// sign-extend the 16-bit delta
+			d := int(int16(uint16(code)))
+			mouseWheelDelta += d
+			mouseWheelRemain += d
+			n := mouseWheelRemain / wheelDelta // truncates toward zero
+			mouseWheelNotches += n
+			mouseWheelRemain -= n * wheelDelta
+		case ActionEnter:
+			atomic.StoreUint32(&mouseInWindow, 1)
+		case ActionLeave:
+			atomic.StoreUint32(&mouseInWindow, 0)
+		}
+		mouseStateMu.Unlock()
+		keyStateMu.Lock()
+		currentMods = mods
+		keyStateMu.Unlock()
+	}
+	inputHandlerMu.RLock()
+	ih := inputHandler
+	inputHandlerMu.RUnlock()
+	if ih != nil {
+		ih(kind, code, action, mods, x, y)
 	}
-	pRegisterInputCallback.Call(inputCallbackPtr)
 }
 
 // ensureInputCallbackRegistered ensures the native input callback is installed
@@ -1171,72 +1695,14 @@ func RegisterInputHandler(h InputHandler) {
 // RegisterInputHandler, their handler will be invoked after internal state updates.
 func ensureInputCallbackRegistered() {
 	if pRegisterInputCallback == nil {
+		logf(LogWarn, "register_input_callback unavailable; input helpers will not update")
 		return
 	}
 	if inputCallbackPtr == 0 {
-		inputCallbackPtr = syscall.NewCallback(func(kind, codeWithMods, action, packedXY uintptr) uintptr {
-			ik := int(kind)
-			cwm := uint32(codeWithMods)
-			code := int(cwm & 0xFFFF)
-			mods := int((cwm >> 16) & 0xFFFF)
-			ac := int(action)
-			pxy := uint64(packedXY)
-			x := int(uint32(pxy & 0xFFFFFFFF))
-			y := int(uint32(pxy >> 32))
-
-			switch ik {
-			case EventKindKey:
-				keyStateMu.Lock()
-				switch ac {
-				case ActionDown:
-					if !keyDown[code] {
-						keyPressedOnce[code] = true
-						keyPressQueue = append(keyPressQueue, code)
-						keyDown[code] = true
-						for _, r := range translateVKToRunes(code, mods) {
-							charPressQueue = append(charPressQueue, int(r))
-						}
-					} else {
-						keyRepeat[code] = true
-					}
-				case ActionUp:
-					if keyDown[code] {
-						keyReleasedOnce[code] = true
-						delete(keyDown, code)
-					}
-				}
-				currentMods = mods
-				keyStateMu.Unlock()
-			case EventKindMouse:
-				mouseStateMu.Lock()
-				mouseX, mouseY = x, y
-				switch ac {
-				case ActionDown:
-					if !mouseDown[code] {
-						mousePressedOnce[code] = true
-						mouseDown[code] = true
-					}
-				case ActionUp:
-					if mouseDown[code] {
-						mouseReleasedOnce[code] = true
-						delete(mouseDown, code)
-					}
-				}
-				mouseStateMu.Unlock()
-				keyStateMu.Lock()
-				currentMods = mods
-				keyStateMu.Unlock()
-			}
-			inputHandlerMu.RLock()
-			ih := inputHandler
-			inputHandlerMu.RUnlock()
-			if ih != nil {
-				ih(ik, code, ac, mods, x, y)
-			}
-			return 0
-		})
+		inputCallbackPtr = syscall.NewCallback(dispatchInput)
 	}
 	pRegisterInputCallback.Call(inputCallbackPtr)
+	logf(LogDebug, "input callback registered")
 }
 
 // RegisterCloseHandler installs a callback invoked immediately when the native
@@ -1246,6 +1712,7 @@ func RegisterCloseHandler(fn func()) {
 	closeHandler = fn
 	closeHandlerMu.Unlock()
 	if pRegisterCloseCallback == nil {
+		logf(LogWarn, "close handler stored but register_close_callback is unavailable")
 		return
 	}
 	// Create callback once; native signature: void cb()
@@ -1275,9 +1742,103 @@ func PollEvents(max int) ([]Event, bool) {
 	if count < 0 || count > max {
 		count = 0
 	}
+	for _, ev := range buf[:count] {
+		switch {
+		case ev.Kind == EventKindDPIChanged && ev.Code > 0:
+			atomic.StoreUint32(&cachedDPI, uint32(ev.Code))
+		case ev.Kind == EventKindMoved:
+			windowMovedPos.Store(uint64(uint32(ev.X)) | uint64(uint32(ev.Y))<<32)
+			atomic.StoreUint32(&windowMovedFlag, 1)
+		case ev.Kind == EventKindFocus && ev.Action == ActionFocusLost:
+			if atomic.LoadUint32(&clearInputOnFocusLoss) != 0 {
+				releaseHeldInput()
+			}
+		}
+	}
+	pullRawMouseDelta()
 	return buf[:count], more != 0
 }
 
+// Event queue overflow policies for SetEventOverflowPolicy.
+const (
+	EventOverflowDropOldest = 0 // keep the most recent events (default)
+	EventOverflowDropNewest = 1 // keep the backlog, discard new events
+)
+
+// optional event queue procs; nil when the DLL predates them
+var pSetEventQueueCapacity, pSetEventOverflowPolicy, pGetDroppedEventCount *nativeProc
+
+// SetEventQueueCapacity sets how many events the native queue holds between
+// PollEvents calls (default 255, minimum 1). Raise it for loops that may
+// stall; shrinking below the current backlog drops events per the overflow
+// policy.
+func SetEventQueueCapacity(n int) {
+	if pSetEventQueueCapacity == nil {
+		return
+	}
+	if n < 1 {
+		n = 1
+	}
+	pSetEventQueueCapacity.Call(uintptr(int32(n)))
+}
+
+// SetEventOverflowPolicy selects which event is dropped when the queue is full
+// (EventOverflowDropOldest or EventOverflowDropNewest). Unknown values are ignored.
+func SetEventOverflowPolicy(policy int) {
+	if pSetEventOverflowPolicy == nil {
+		return
+	}
+	pSetEventOverflowPolicy.Call(uintptr(int32(policy)))
+}
+
+// GetDroppedEventCount returns the number of events discarded because the
+// queue was full, since the DLL was loaded. Compare successive values to
+// detect input loss; returns 0 if the DLL does not track drops.
+func GetDroppedEventCount() uint64 {
+	if pGetDroppedEventCount == nil {
+		return 0
+	}
+	r, _, _ := pGetDroppedEventCount.Call()
+	return uint64(r)
+}
+
+// optional blocking wait on the native queue; nil when the DLL predates it
+var pWaitForEvents, pPostWakeEvent *nativeProc
+
+// waitPollStep is the polling interval of WaitForEvent without native support.
+const waitPollStep = 5 * time.Millisecond
+
+// WaitForEvent blocks until at least one native event is queued or timeout
+// elapses (timeout <= 0 waits indefinitely), then drains up to 64 events like
+// PollEvents. The bool is false if the wait timed out. With a DLL lacking
+// winui_wait_for_events it falls back to polling every few milliseconds.
+func WaitForEvent(timeout time.Duration) ([]Event, bool) {
+	if pPollEvents == nil {
+		return nil, false
+	}
+	if pWaitForEvents != nil {
+		ms := int32(-1)
+		if timeout > 0 {
+			ms = int32(min(timeout.Milliseconds(), math.MaxInt32))
+		}
+		if r, _, _ := pWaitForEvents.Call(uintptr(ms)); r == 0 {
+			return nil, false
+		}
+		evs, _ := PollEvents(64)
+		return evs, len(evs) > 0
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		if evs, _ := PollEvents(64); len(evs) > 0 {
+			return evs, true
+		}
+		if timeout > 0 && !time.Now().Before(deadline) {
+			return nil, false
+		}
+		time.Sleep(waitPollStep)
+	}
+}
+
 // PollEventsFrame polls up to max events then performs per-frame housekeeping
 // by calling ResetKeyTransitions(). Prefer this in simple loops where you do
 // not need to manually control the timing of transition resets.
@@ -1306,7 +1867,8 @@ func GetScreenHeight() int {
 
 // Window rectangle and movement -------------------------------------------
 
-// GetWindowPosition returns the top-left client window position in screen coords.
+// GetWindowPosition returns the top-left corner of the outer window (including
+// the non-client frame) in screen coords. It pairs with SetWindowPosition.
 func GetWindowPosition() (x, y int) {
 	h := getHWND()
 	if h == 0 || procGetWindowRect.Find() != nil {
@@ -1317,6 +1879,39 @@ func GetWindowPosition() (x, y int) {
 	return int(rc.Left), int(rc.Top)
 }
 
+// GetWindowClientPosition returns the top-left corner of the client area in
+// screen coords. It differs from GetWindowPosition by the border and title bar
+// thickness; use it to place overlays relative to the window content.
+func GetWindowClientPosition() (x, y int) { return ClientToScreen(0, 0) }
+
+// point mirrors the Win32 POINT struct.
+type point struct{ X, Y int32 }
+
+// ClientToScreen converts client-area pixel coordinates (as reported by the
+// mouse helpers) to screen coordinates. Returns x,y unchanged if the window
+// is unavailable.
+func ClientToScreen(x, y int) (int, int) {
+	h := getHWND()
+	if h == 0 || procClientToScreen.Find() != nil {
+		return x, y
+	}
+	pt := point{int32(x), int32(y)}
+	procClientToScreen.Call(h, uintptr(unsafe.Pointer(&pt)))
+	return int(pt.X), int(pt.Y)
+}
+
+// ScreenToClient converts screen coordinates (e.g. from GetCursorPos) to
+// client-area pixel coordinates. Returns x,y unchanged if the window is unavailable.
+func ScreenToClient(x, y int) (int, int) {
+	h := getHWND()
+	if h == 0 || procScreenToClient.Find() != nil {
+		return x, y
+	}
+	pt := point{int32(x), int32(y)}
+	procScreenToClient.Call(h, uintptr(unsafe.Pointer(&pt)))
+	return int(pt.X), int(pt.Y)
+}
+
 // SetWindowPosition moves the window to x,y.
 func SetWindowPosition(x, y int) {
 	h := getHWND()
@@ -1326,12 +1921,51 @@ func SetWindowPosition(x, y int) {
 	procSetWindowPos.Call(h, 0, uintptr(int32(x)), uintptr(int32(y)), 0, 0, uintptr(SWP_NOSIZE|SWP_NOZORDER|SWP_NOOWNERZORDER|SWP_NOSENDCHANGING))
 }
 
-// SetWindowSize resizes the outer window to width/height.
+// moveVisibleMargin is how much of the title bar MoveWindowBy keeps on screen
+// horizontally (the whole bar if the window is narrower).
+const moveVisibleMargin = 100
+
+// MoveWindowBy moves the window by dx,dy pixels from its current position,
+// e.g. for arrow-key nudging. The result is clamped so the title bar stays
+// reachable: its top edge stays inside the work area of the nearest monitor
+// and at least part of it remains horizontally on that monitor.
+func MoveWindowBy(dx, dy int) {
+	h := getHWND()
+	if h == 0 || procGetWindowRect.Find() != nil {
+		return
+	}
+	var rc rect
+	if r, _, _ := procGetWindowRect.Call(h, uintptr(unsafe.Pointer(&rc))); r == 0 {
+		return
+	}
+	x, y := int(rc.Left)+dx, int(rc.Top)+dy
+	ow := int(rc.Right - rc.Left)
+	capH := 0
+	if procGetSystemMetrics.Find() == nil {
+		r, _, _ := procGetSystemMetrics.Call(uintptr(SM_CYCAPTION))
+		capH = int(r)
+	}
+	if mi, ok := getMonitorInfo(monitorFromPoint(x+ow/2, y, monitorDefaultNearest)); ok {
+		wa := mi.RcWork
+		keep := min(ow, moveVisibleMargin)
+		x = max(min(x, int(wa.Right)-keep), int(wa.Left)-ow+keep)
+		y = max(min(y, int(wa.Bottom)-capH), int(wa.Top))
+	}
+	SetWindowPosition(x, y)
+}
+
+// SetWindowSize resizes the outer window to width/height. The resulting client
+// area is clamped to the hints of SetWindowMinSize/SetWindowMaxSize (zero
+// components are unconstrained), so programmatic resizes honor the same
+// limits as a user drag.
 func SetWindowSize(width, height int) {
 	h := getHWND()
 	if h == 0 || procSetWindowPos.Find() != nil {
 		return
 	}
+	width, height = clampOuterSize(width, height)
+	programmaticSize.Add(1)
+	defer programmaticSize.Add(-1)
 	procSetWindowPos.Call(h, 0, 0, 0, uintptr(int32(width)), uintptr(int32(height)), uintptr(SWP_NOMOVE|SWP_NOZORDER|SWP_NOOWNERZORDER|SWP_NOSENDCHANGING|SWP_FRAMECHANGED))
 }
 
@@ -1388,24 +2022,268 @@ func SetWindowOpacity(alpha float64) {
 		procSetWindowLongPtrW.Call(h, uintptr(idxEx), styleEx|WS_EX_LAYERED)
 	}
 	a := byte(int(math.Round(alpha * 255)))
+	key, _, flags := layeredAttrs(h) // keep a color key
+	procSetLayeredAttr.Call(h, uintptr(key), uintptr(a), uintptr(flags&LWA_COLORKEY|LWA_ALPHA))
+}
+
+// layeredAttrs returns the layered attributes of h; zero flags if none are set.
+func layeredAttrs(h uintptr) (key uint32, alpha byte, flags uint32) {
+	if procGetLayeredAttr.Find() != nil {
+		return 0, 0, 0
+	}
+	if r, _, _ := procGetLayeredAttr.Call(h, uintptr(unsafe.Pointer(&key)), uintptr(unsafe.Pointer(&alpha)), uintptr(unsafe.Pointer(&flags))); r == 0 {
+		return 0, 0, 0
+	}
+	return key, alpha, flags
+}
+
+// SetWindowColorKey makes every pixel of exactly color c (alpha ignored) fully
+// transparent and click-through (LWA_COLORKEY), a cheap way to shape an
+// overlay: paint the background in the key color. Antialiased edges blend
+// toward the key and keep a fringe of near-key pixels. Combines with
+// SetWindowOpacity.
+func SetWindowColorKey(c Color) {
+	h := getHWND()
+	if h == 0 || procGetWindowLongPtrW.Find() != nil || procSetWindowLongPtrW.Find() != nil || procSetLayeredAttr.Find() != nil {
+		return
+	}
+	idxEx := int32(GWL_EXSTYLE)
+	styleEx, _, _ := procGetWindowLongPtrW.Call(h, uintptr(idxEx))
+	var alpha byte = 255
+	var flags uint32
+	if (styleEx & WS_EX_LAYERED) == 0 {
+		procSetWindowLongPtrW.Call(h, uintptr(idxEx), styleEx|WS_EX_LAYERED)
+	} else if _, a, f := layeredAttrs(h); f&LWA_ALPHA != 0 {
+		alpha, flags = a, LWA_ALPHA // keep the opacity
+	}
+	_, r, g, b := c.ARGB()
+	key := uint32(b)<<16 | uint32(g)<<8 | uint32(r) // COLORREF 0x00BBGGRR
+	procSetLayeredAttr.Call(h, uintptr(key), uintptr(alpha), uintptr(flags|LWA_COLORKEY))
+}
+
+// ClearWindowColorKey removes the color key of SetWindowColorKey; a window
+// opacity set with SetWindowOpacity is kept.
+func ClearWindowColorKey() {
+	h := getHWND()
+	if h == 0 || procSetLayeredAttr.Find() != nil {
+		return
+	}
+	_, a, flags := layeredAttrs(h)
+	if flags&LWA_COLORKEY == 0 {
+		return
+	}
+	if flags&LWA_ALPHA == 0 {
+		// a layered window without attributes is not drawn; stay fully opaque
+		a = 255
+	}
 	procSetLayeredAttr.Call(h, 0, uintptr(a), uintptr(LWA_ALPHA))
 }
 
+// SetWindowClickThrough makes the window ignore mouse input so clicks reach the
+// windows beneath it (WS_EX_TRANSPARENT | WS_EX_LAYERED). Combine with
+// SetWindowOpacity for HUD overlays. Turning it off keeps the window layered
+// so a previously set opacity is preserved.
+func SetWindowClickThrough(on bool) {
+	h := getHWND()
+	if h == 0 || procGetWindowLongPtrW.Find() != nil || procSetWindowLongPtrW.Find() != nil || procSetLayeredAttr.Find() != nil {
+		return
+	}
+	idxEx := int32(GWL_EXSTYLE)
+	styleEx, _, _ := procGetWindowLongPtrW.Call(h, uintptr(idxEx))
+	if !on {
+		procSetWindowLongPtrW.Call(h, uintptr(idxEx), styleEx&^WS_EX_TRANSPARENT)
+		return
+	}
+	if (styleEx & WS_EX_LAYERED) == 0 {
+		procSetWindowLongPtrW.Call(h, uintptr(idxEx), styleEx|WS_EX_LAYERED|WS_EX_TRANSPARENT)
+		// a layered window without attributes is not drawn; start fully opaque
+		procSetLayeredAttr.Call(h, 0, 255, uintptr(LWA_ALPHA))
+		return
+	}
+	procSetWindowLongPtrW.Call(h, uintptr(idxEx), styleEx|WS_EX_TRANSPARENT)
+}
+
+// SetWindowNoActivate sets or clears WS_EX_NOACTIVATE: clicking the window no
+// longer activates it or takes focus from the active application, and it stays
+// out of the taskbar and Alt+Tab. Use it for tooltips and tool palettes, and
+// show such windows with ShowWindowNoActivate.
+func SetWindowNoActivate(on bool) {
+	h := getHWND()
+	if h == 0 || procGetWindowLongPtrW.Find() != nil || procSetWindowLongPtrW.Find() != nil {
+		return
+	}
+	idxEx := int32(GWL_EXSTYLE)
+	styleEx, _, _ := procGetWindowLongPtrW.Call(h, uintptr(idxEx))
+	if on {
+		styleEx |= WS_EX_NOACTIVATE
+	} else {
+		styleEx &^= WS_EX_NOACTIVATE
+	}
+	procSetWindowLongPtrW.Call(h, uintptr(idxEx), styleEx)
+}
+
+// SetWindowInTaskSwitcher shows (on) or hides the window in Alt+Tab and the
+// taskbar, e.g. to keep an overlay or HUD out of them. Off makes it a tool
+// window (WS_EX_TOOLWINDOW), which also draws a slimmer caption; on clears
+// that and sets WS_EX_APPWINDOW. The shell only reads these bits when a window
+// is shown, so a visible window is briefly hidden and shown again.
+func SetWindowInTaskSwitcher(on bool) {
+	h := getHWND()
+	if h == 0 || procShowWindow.Find() != nil {
+		return
+	}
+	ex := GetWindowExStyle()
+	if on {
+		ex = ex&^WS_EX_TOOLWINDOW | WS_EX_APPWINDOW
+	} else {
+		ex = ex&^WS_EX_APPWINDOW | WS_EX_TOOLWINDOW
+	}
+	visible := !IsWindowHidden()
+	if visible {
+		procShowWindow.Call(h, uintptr(SW_HIDE))
+	}
+	SetWindowExStyle(ex)
+	if visible {
+		// SW_SHOWNA keeps focus with the foreground app (overlays, HUDs)
+		procShowWindow.Call(h, uintptr(SW_SHOWNA))
+	}
+}
+
+// SetWindowMaximizeEnabled adds or removes the maximize button
+// (WS_MAXIMIZEBOX). Without it the user cannot maximize the window from the
+// title bar or by double-clicking the caption; MaximizeWindow still works.
+// With the minimize button also removed, the caption keeps only the close
+// button.
+func SetWindowMaximizeEnabled(on bool) { setWindowStyleBits(WS_MAXIMIZEBOX, on) }
+
+// SetWindowMinimizeEnabled adds or removes the minimize button (WS_MINIMIZEBOX).
+func SetWindowMinimizeEnabled(on bool) { setWindowStyleBits(WS_MINIMIZEBOX, on) }
+
+// setWindowStyleBits sets or clears bits in GWL_STYLE and redraws the frame.
+func setWindowStyleBits(bits uint32, on bool) {
+	style := GetWindowStyle()
+	if on {
+		style |= bits
+	} else {
+		style &^= bits
+	}
+	SetWindowStyle(style)
+}
+
+// GetWindowStyle returns the window style (GWL_STYLE, the WS_* bits), or 0 if
+// the window is unavailable.
+func GetWindowStyle() uint32 { return getWindowLong(GWL_STYLE) }
+
+// GetWindowExStyle returns the extended window style (GWL_EXSTYLE, the
+// WS_EX_* bits), or 0 if the window is unavailable.
+func GetWindowExStyle() uint32 { return getWindowLong(GWL_EXSTYLE) }
+
+// SetWindowStyle replaces the window style (GWL_STYLE) and redraws the frame.
+// It is an escape hatch for styles this package does not wrap; read the
+// current value with GetWindowStyle and change only the bits you need. Wrong
+// combinations (clearing WS_VISIBLE, adding WS_CHILD) can hide or break the
+// window, and the wrapped setters (fullscreen, the button toggles) assume
+// the style they left behind.
+func SetWindowStyle(style uint32) { setWindowLong(GWL_STYLE, style) }
+
+// SetWindowExStyle replaces the extended window style (GWL_EXSTYLE) and
+// redraws the frame. The caveats of SetWindowStyle apply; WS_EX_LAYERED in
+// particular is managed by the opacity and click-through functions.
+func SetWindowExStyle(style uint32) { setWindowLong(GWL_EXSTYLE, style) }
+
+func getWindowLong(idx int32) uint32 {
+	h := getHWND()
+	if h == 0 || procGetWindowLongPtrW.Find() != nil {
+		return 0
+	}
+	v, _, _ := procGetWindowLongPtrW.Call(h, uintptr(idx))
+	return uint32(v)
+}
+
+// setWindowLong stores a style value and applies it with SWP_FRAMECHANGED;
+// style changes do not take visual effect until then.
+func setWindowLong(idx int32, v uint32) {
+	h := getHWND()
+	if h == 0 || procSetWindowLongPtrW.Find() != nil || procSetWindowPos.Find() != nil {
+		return
+	}
+	procSetWindowLongPtrW.Call(h, uintptr(idx), uintptr(v))
+	procSetWindowPos.Call(h, 0, 0, 0, 0, 0, uintptr(SWP_NOMOVE|SWP_NOSIZE|SWP_NOZORDER|SWP_NOOWNERZORDER|SWP_NOACTIVATE|SWP_FRAMECHANGED))
+}
+
+// ShowWindowNoActivate shows the window without activating it
+// (SW_SHOWNOACTIVATE), so focus stays with the user's current window.
+func ShowWindowNoActivate() {
+	h := getHWND()
+	if h != 0 && procShowWindow.Find() == nil {
+		procShowWindow.Call(h, uintptr(SW_SHOWNOACTIVATE))
+	}
+}
+
+// GetWindowOpacity returns the layered-window alpha 0..1. Windows that are not
+// layered (or have no alpha set) report 1.0.
+func GetWindowOpacity() float64 {
+	h := getHWND()
+	if h == 0 || procGetWindowLongPtrW.Find() != nil || procGetLayeredAttr.Find() != nil {
+		return 1
+	}
+	idxEx := int32(GWL_EXSTYLE)
+	styleEx, _, _ := procGetWindowLongPtrW.Call(h, uintptr(idxEx))
+	if (styleEx & WS_EX_LAYERED) == 0 {
+		return 1
+	}
+	var key uint32
+	var a byte
+	var flags uint32
+	r1, _, _ := procGetLayeredAttr.Call(h, uintptr(unsafe.Pointer(&key)), uintptr(unsafe.Pointer(&a)), uintptr(unsafe.Pointer(&flags)))
+	if r1 == 0 || (flags&LWA_ALPHA) == 0 {
+		return 1
+	}
+	return float64(a) / 255
+}
+
 // DPI scale ----------------------------------------------------------------
 
-// GetWindowScaleDPI returns scale factors relative to 96 DPI.
+// cachedDPI holds the window DPI last read by GetWindowScaleDPI; 0 means
+// unknown. It is updated from EventKindDPIChanged events seen by PollEvents
+// and cleared by every native resize (a DPI change always resizes), so the
+// DIP helpers avoid a syscall per call.
+var cachedDPI uint32
+
+// GetWindowScaleDPI returns scale factors relative to 96 DPI. The DPI is
+// cached and refreshed on DPI change; see RefreshDPICache. Before the window
+// exists it reports the primary monitor's scale, where new windows usually
+// open, so early size calculations are approximately right.
 func GetWindowScaleDPI() (sx, sy float64) {
+	d := atomic.LoadUint32(&cachedDPI)
+	if d == 0 {
+		d = queryWindowDPI()
+		if d == 0 {
+			// not cached, so the window's own DPI takes over once it exists
+			s := monitorScale(monitorFromPoint(0, 0, monitorDefaultToPrimary))
+			return s, s
+		}
+		atomic.StoreUint32(&cachedDPI, d)
+	}
+	s := float64(d) / 96.0
+	return s, s
+}
+
+// queryWindowDPI asks user32 for the window DPI; 0 if unavailable.
+func queryWindowDPI() uint32 {
 	h := getHWND()
 	if h == 0 || procGetDpiForWindow.Find() != nil {
-		return 1, 1
+		return 0
 	}
 	dpi, _, _ := procGetDpiForWindow.Call(h)
-	d := float64(uint32(dpi))
-	if d <= 0 {
-		return 1, 1
-	}
-	s := d / 96.0
-	return s, s
+	return uint32(dpi)
+}
+
+// RefreshDPICache re-reads the window DPI now. The cache follows DPI changes
+// on its own; call this only if the window was recreated or DPI events are
+// not being polled and no resize followed the change.
+func RefreshDPICache() {
+	atomic.StoreUint32(&cachedDPI, queryWindowDPI())
 }
 
 // Fullscreen handling -------------------------------------------------------
@@ -1425,8 +2303,10 @@ func ToggleFullscreen() {
 		// save current
 		var rc rect
 		procGetWindowRect.Call(h, uintptr(unsafe.Pointer(&rc)))
+		dpi := queryWindowDPI() // takes hwndMu itself
 		hwndMu.Lock()
 		savedRect = rc
+		savedDPI = dpi
 		savedStyle = style
 		ex, _, _ := procGetWindowLongPtrW.Call(h, uintptr(idxEx))
 		savedExStyle = ex
@@ -1435,27 +2315,101 @@ func ToggleFullscreen() {
 		procSetWindowLongPtrW.Call(h, uintptr(idxStyle), uintptr(WS_POPUP|WS_VISIBLE))
 		sw := GetScreenWidth()
 		sh := GetScreenHeight()
-		procSetWindowPos.Call(h, 0, 0, 0, uintptr(int32(sw)), uintptr(int32(sh)), uintptr(SWP_NOZORDER|SWP_NOOWNERZORDER|SWP_FRAMECHANGED))
+		placeWindowRect(h, rect{Right: int32(sw), Bottom: int32(sh)}, SWP_NOZORDER|SWP_NOOWNERZORDER|SWP_FRAMECHANGED)
 	} else {
 		// restore
 		hwndMu.Lock()
 		rc := savedRect
+		dpi := savedDPI
 		st := savedStyle
 		ex := savedExStyle
 		hwndMu.Unlock()
 		if st != 0 {
+			// savedRect is in pixels of the DPI at save time; if the scale of
+			// its monitor changed since, resize it to keep the same DIP size
+			cx, cy := int(rc.Left+rc.Right)/2, int(rc.Top+rc.Bottom)/2
+			target := uint32(monitorScale(monitorFromPoint(cx, cy, monitorDefaultNearest))*96 + 0.5)
+			if dpi != 0 && target != dpi {
+				rc.Right = rc.Left + int32(int64(rc.Right-rc.Left)*int64(target)/int64(dpi))
+				rc.Bottom = rc.Top + int32(int64(rc.Bottom-rc.Top)*int64(target)/int64(dpi))
+			}
 			procSetWindowLongPtrW.Call(h, uintptr(idxStyle), st)
 			procSetWindowLongPtrW.Call(h, uintptr(idxEx), ex)
-			procSetWindowPos.Call(h, 0, uintptr(rc.Left), uintptr(rc.Top), uintptr(rc.Right-rc.Left), uintptr(rc.Bottom-rc.Top), uintptr(SWP_NOZORDER|SWP_NOOWNERZORDER|SWP_FRAMECHANGED))
+			placeWindowRect(h, rc, SWP_NOZORDER|SWP_NOOWNERZORDER|SWP_FRAMECHANGED)
 		}
 	}
 }
 
+// placeWindowRect moves and sizes h to rc (screen pixels). When the move
+// crosses onto a monitor with another DPI, the window handles WM_DPICHANGED
+// by resizing itself to the suggested rect, which scales the size a second
+// time; rc is then applied again so the requested rect wins.
+func placeWindowRect(h uintptr, rc rect, flags uintptr) {
+	before := queryWindowDPI()
+	procSetWindowPos.Call(h, 0, uintptr(rc.Left), uintptr(rc.Top), uintptr(rc.Right-rc.Left), uintptr(rc.Bottom-rc.Top), flags)
+	if after := queryWindowDPI(); after != before {
+		procSetWindowPos.Call(h, 0, uintptr(rc.Left), uintptr(rc.Top), uintptr(rc.Right-rc.Left), uintptr(rc.Bottom-rc.Top), flags&^SWP_FRAMECHANGED)
+	}
+}
+
+// lastInputInfo mirrors the Win32 LASTINPUTINFO struct.
+type lastInputInfo struct {
+	CbSize uint32
+	DwTime uint32
+}
+
+// GetSystemIdleTime returns the time since the user last gave any input to
+// the session (keyboard, mouse, touch, in any application), for presence
+// features such as screensaver-like behavior. Unlike Window.OnIdle it does not
+// depend on this window having focus. Returns 0 if unavailable.
+func GetSystemIdleTime() time.Duration {
+	if procGetLastInputInfo.Find() != nil || procGetTickCount.Find() != nil {
+		return 0
+	}
+	lii := lastInputInfo{CbSize: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	if r, _, _ := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&lii))); r == 0 {
+		return 0
+	}
+	now, _, _ := procGetTickCount.Call()
+	// both are 32-bit tick counts; unsigned subtraction survives the wrap
+	return time.Duration(uint32(now)-lii.DwTime) * time.Millisecond
+}
+
 // Convenience APIs ----------------------------------------------------------
 
 // GetWindowHandle returns the HWND, or 0 if not found.
 func GetWindowHandle() uintptr { return getHWND() }
 
+// ErrWindowNotFound is returned by AttachToWindow when no top-level window has
+// the requested title.
+var ErrWindowNotFound = errors.New("winui: window not found")
+
+// AttachToWindow makes the window management functions (GetWindowPosition,
+// SetWindowSize, MaximizeWindow, ...) operate on the existing top-level window
+// whose title is exactly title, which may belong to another process, and
+// returns its HWND. The attachment lasts until CreateWindow or SetWindowTitle
+// resets the handle lookup. Functions implemented by WinUI3Native.dll
+// (controls, events, the title itself) keep targeting this process's window.
+func AttachToWindow(title string) (uintptr, error) {
+	if err := procFindWindowW.Find(); err != nil {
+		return 0, err
+	}
+	t16, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return 0, err
+	}
+	h, _, _ := procFindWindowW.Call(0, uintptr(unsafe.Pointer(t16)))
+	if h == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrWindowNotFound, title)
+	}
+	hwndMu.Lock()
+	lastWindowTitle = title
+	cachedHWND = h
+	hwndMu.Unlock()
+	atomic.StoreUint32(&cachedDPI, 0) // belonged to the previous window
+	return h, nil
+}
+
 // IsWindowFullscreen tries to detect borderless fullscreen state.
 func IsWindowFullscreen() bool {
 	h := getHWND()
@@ -1514,6 +2468,37 @@ func ApplyMinMaxConstraints() {
 	minSizeMu.Unlock()
 	pSetWindowMinMax.Call(uintptr(int32(wmin)), uintptr(int32(hmin)), uintptr(int32(wmax)), uintptr(int32(hmax)))
 }
+
+// clampClientSize limits a client size to the stored min/max hints.
+func clampClientSize(cw, ch int) (int, int) {
+	minSizeMu.Lock()
+	wmin, hmin, wmax, hmax := minW, minH, maxW, maxH
+	minSizeMu.Unlock()
+	if wmax > 0 && cw > wmax {
+		cw = wmax
+	}
+	if hmax > 0 && ch > hmax {
+		ch = hmax
+	}
+	if wmin > 0 && cw < wmin {
+		cw = wmin
+	}
+	if hmin > 0 && ch < hmin {
+		ch = hmin
+	}
+	return cw, ch
+}
+
+// clampOuterSize applies clampClientSize to an outer window size, using the
+// current frame thickness to convert between outer and client sizes.
+func clampOuterSize(ow, oh int) (int, int) {
+	curW, curH := GetWindowOuterSize()
+	cliW, cliH := GetWindowClientSize()
+	dx, dy := max(curW-cliW, 0), max(curH-cliH, 0)
+	cw, ch := clampClientSize(ow-dx, oh-dy)
+	return cw + dx, ch + dy
+}
+
 func GetWindowMinSize() (int, int) {
 	minSizeMu.Lock()
 	w, h := minW, minH
@@ -1597,6 +2582,23 @@ func IsWindowFocused() bool {
 // IsWindowResized returns true if a resize happened since last ResetKeyTransitions.
 func IsWindowResized() bool { return atomic.LoadUint32(&windowResizedFlag) != 0 }
 
+// IsWindowResizedByUser is like IsWindowResized but ignores resizes made by
+// SetWindowSize (and the Window size setters built on it), leaving those made
+// by the user or the system: frame drags, maximizing, snapping, DPI changes.
+func IsWindowResizedByUser() bool { return atomic.LoadUint32(&windowUserResized) != 0 }
+
+// IsWindowMoved returns true if PollEvents delivered a move since the last
+// ResetKeyTransitions. Moves are reported through the event queue, so the
+// loop must poll events for this to update.
+func IsWindowMoved() bool { return atomic.LoadUint32(&windowMovedFlag) != 0 }
+
+// GetWindowMovePosition returns the outer window origin (as GetWindowPosition)
+// reported by the most recent move event, or 0,0 if none was seen.
+func GetWindowMovePosition() (x, y int) {
+	v := windowMovedPos.Load()
+	return int(int32(uint32(v))), int(int32(uint32(v >> 32)))
+}
+
 // SetWindowFocused brings the window to foreground, if possible.
 func SetWindowFocused() {
 	h := getHWND()
@@ -1605,6 +2607,52 @@ func SetWindowFocused() {
 	}
 }
 
+// ForceWindowToFront restores the window if minimized and raises it to the
+// foreground even when another process owns it. Windows' foreground-lock rules
+// make SetForegroundWindow (and thus SetWindowFocused) a silent no-op in that
+// case; this temporarily attaches the calling thread's input queue to the
+// foreground thread so the request is honored. Returns true if the window is
+// foreground afterwards.
+//
+// Caveats: use it only in direct response to user intent (e.g. a global hotkey),
+// since stealing focus otherwise is hostile. While attached, the two threads
+// share key state for a moment. Some hosts (elevated foreground windows, the
+// lock screen, full-screen exclusive games) can still refuse the request.
+func ForceWindowToFront() bool {
+	h := getHWND()
+	if h == 0 || procSetForegroundWnd.Find() != nil || procGetForegroundWnd.Find() != nil {
+		return false
+	}
+	if procIsIconic.Find() == nil && procShowWindow.Find() == nil {
+		if r, _, _ := procIsIconic.Call(h); r != 0 {
+			procShowWindow.Call(h, uintptr(SW_RESTORE))
+		}
+	}
+	fg, _, _ := procGetForegroundWnd.Call()
+	if fg == h {
+		return true
+	}
+	// AttachThreadInput is per OS thread; keep attach/detach on the same one.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	cur := windows.GetCurrentThreadId()
+	var fgTid uint32
+	if fg != 0 {
+		fgTid, _ = windows.GetWindowThreadProcessId(windows.HWND(fg), nil)
+	}
+	if fgTid != 0 && fgTid != cur && procAttachThreadInput.Find() == nil {
+		if r, _, _ := procAttachThreadInput.Call(uintptr(cur), uintptr(fgTid), 1); r != 0 {
+			defer procAttachThreadInput.Call(uintptr(cur), uintptr(fgTid), 0)
+		}
+	}
+	if procBringWindowToTop.Find() == nil {
+		procBringWindowToTop.Call(h)
+	}
+	procSetForegroundWnd.Call(h)
+	f, _, _ := procGetForegroundWnd.Call()
+	return f == h
+}
+
 // Run provides a minimal, raylib-style loop: it paces to SetTargetFPS(),
 // internally polls events and manages per-frame input transitions, and calls
 // update() each frame. Return false from update() to exit early. The function
@@ -1665,16 +2713,12 @@ func Run(update func() bool) {
 		// the next frame's update.
 		ResetKeyTransitions()
 
-		fps := atomic.LoadInt32(&targetFPS)
-		if fps <= 0 {
-			fps = 60
-		}
-		desiredNS := int64(math.Round(1e9 / float64(fps)))
+		desiredNS := frameBudget().Nanoseconds()
 		workNS := time.Since(frameStart).Nanoseconds()
 		if sleepNS := desiredNS - workNS; sleepNS > 0 {
-			time.Sleep(time.Duration(sleepNS))
+			paceSleep(time.Duration(sleepNS))
 		}
-		atomic.StoreInt64(&lastFrameNS, time.Since(frameStart).Nanoseconds())
+		recordFrameTime(time.Since(frameStart).Nanoseconds())
 	}
 
 	select {
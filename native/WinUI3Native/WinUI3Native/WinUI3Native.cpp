@@ -4,10 +4,15 @@
 #include "pch.h"
 #include "WinUI3Native.h"
 #include <map>
+#include <vector>
+#include <string>
+#include <deque>
+#include <set>
 #include <thread>
 #include <mutex>
 #include <condition_variable>
 #include <future>
+#include <functional>
 #include <atomic>
 #include <winrt/Microsoft.UI.Xaml.h>
 #include <winrt/Microsoft.UI.Xaml.Controls.h>
@@ -16,12 +21,17 @@
 #include <winrt/Microsoft.UI.Input.h>
 #include <winrt/Microsoft.UI.Dispatching.h>
 #include <winrt/Windows.UI.Text.h>
+#include <winrt/Windows.Graphics.h>
 #include <MddBootstrap.h>
 #include <Windows.h>
+#include <shellapi.h>
+#include <shobjidl.h>
 #include <psapi.h>
 #pragma comment(lib, "Psapi.lib")
 #include <dbghelp.h>
 #pragma comment(lib, "Dbghelp.lib")
+#include <wtsapi32.h>
+#pragma comment(lib, "Wtsapi32.lib")
 
 // Needed for IWindowNative to extract HWND from Microsoft::UI::Xaml::Window
 #include <microsoft.ui.xaml.window.h>
@@ -49,6 +59,11 @@ static std::atomic<unsigned int> g_pendingBGARGB{ 0 }; // 0xAARRGGBB
 // with an existing window will resize immediately.
 static std::atomic<int> g_pendingInitialWidth{ 0 };
 static std::atomic<int> g_pendingInitialHeight{ 0 };
+// Pending outer top-left position (screen pixels) applied before the window is
+// first shown, so it appears directly on the intended monitor.
+static std::atomic<bool> g_pendingInitialPosSet{ false };
+static std::atomic<int> g_pendingInitialX{ 0 };
+static std::atomic<int> g_pendingInitialY{ 0 };
 
 static void LogSeq(const wchar_t* msg) {
     int n = ++g_shutdownSeq;
@@ -236,6 +251,9 @@ static Microsoft::UI::Xaml::FrameworkElement g_originalRootFE{ nullptr };
 static resize_callback_t g_resizeCallback = nullptr;
 static input_event_callback_t g_inputCallback = nullptr;
 static close_callback_t g_closeCallback = nullptr;
+static text_changed_callback_t g_textChangedCallback = nullptr;
+static selection_changed_callback_t g_selectionChangedCallback = nullptr;
+static control_event_callback_t g_controlEventCallback = nullptr;
 static int g_lastPointerButton = 0;
 // Aggregate modifier bits (legacy): 1=Shift 2=Ctrl 4=Alt 8=Win
 // Side-specific modifier bit mask (v2):
@@ -253,32 +271,85 @@ static int ComputeMods(){
     return m;
 }
 
-// Unified event queue (single-producer (UI thread) / multi-consumer safe read) ------
+// Pointer position in client-area physical pixels. XAML reports DIPs relative
+// to the element passed to GetCurrentPoint; measure against the root grid (which
+// fills the client area) and apply the rasterization scale.
+static POINT PointerClientPixels(Microsoft::UI::Xaml::Input::PointerRoutedEventArgs const& args) {
+    POINT pt{ 0, 0 };
+    try {
+        auto pos = args.GetCurrentPoint(g_overlayRoot).Position();
+        double scale = 1.0;
+        if (g_overlayRoot && g_overlayRoot.XamlRoot()) scale = g_overlayRoot.XamlRoot().RasterizationScale();
+        pt.x = static_cast<int>(pos.X * scale);
+        pt.y = static_cast<int>(pos.Y * scale);
+    } catch(...) {}
+    return pt;
+}
+
+// Button whose state change raised a pointer press or release: 1 left,
+// 2 right, 3 middle, 4 XButton1 (back), 5 XButton2 (forward); 0 if unknown.
+static int PointerChangedButton(Microsoft::UI::Input::PointerPointProperties const& props) {
+    using Kind = Microsoft::UI::Input::PointerUpdateKind;
+    switch (props.PointerUpdateKind()) {
+    case Kind::LeftButtonPressed:   case Kind::LeftButtonReleased:   return 1;
+    case Kind::RightButtonPressed:  case Kind::RightButtonReleased:  return 2;
+    case Kind::MiddleButtonPressed: case Kind::MiddleButtonReleased: return 3;
+    case Kind::XButton1Pressed:     case Kind::XButton1Released:     return 4;
+    case Kind::XButton2Pressed:     case Kind::XButton2Released:     return 5;
+    default: return 0;
+    }
+}
+
+// Unified event queue (bounded FIFO guarded by g_eventMutex) -----------------
 struct WinUIEventInternal {
     int kind;  // 1=key 2=mouse 3=resize 4=window_closed 5=window_created
     int code;  // key: vk, mouse: button, resize/window: 0
-    int action; // key:1=down 2=up mouse:1=down 2=up
+    int action; // key:1=down 2=up mouse:1=down 2=up 4=wheel (code=delta)
     int mods;  // side-specific bitmask
     int x;
     int y;
     double w;  // resize width
     double h;  // resize height
 };
-static constexpr int kEventRingSize = 256;
-static WinUIEventInternal g_eventRing[kEventRingSize];
-static std::atomic<int> g_eventHead{0}; // next write
-static std::atomic<int> g_eventTail{0}; // next read
-static std::atomic<int> g_eventOverflow{0};
-static void EnqueueEvent(const WinUIEventInternal& ev){
-    int head = g_eventHead.load(std::memory_order_relaxed);
-    int tail = g_eventTail.load(std::memory_order_acquire);
-    int next = (head + 1) % kEventRingSize;
-    if (next == tail) { // full -> drop oldest
+static constexpr int kDefaultEventQueueCapacity = 255;
+static std::mutex g_eventMutex;
+static std::condition_variable g_eventCv; // signaled on every enqueue
+static std::deque<WinUIEventInternal> g_eventQueue;
+static size_t g_eventCapacity = kDefaultEventQueueCapacity;
+static int g_eventOverflowPolicy = WINUI_EVENT_OVERFLOW_DROP_OLDEST;
+static std::atomic<unsigned long long> g_eventOverflow{0};
+// Drops events until the queue fits cap; caller holds g_eventMutex.
+static void TrimEventQueueLocked(size_t cap){
+    while (g_eventQueue.size() > cap) {
+        if (g_eventOverflowPolicy == WINUI_EVENT_OVERFLOW_DROP_NEWEST) g_eventQueue.pop_back();
+        else g_eventQueue.pop_front();
         g_eventOverflow.fetch_add(1, std::memory_order_relaxed);
-        g_eventTail.store((tail + 1) % kEventRingSize, std::memory_order_release);
     }
-    g_eventRing[head] = ev;
-    g_eventHead.store(next, std::memory_order_release);
+}
+static void EnqueueEvent(const WinUIEventInternal& ev){
+    std::lock_guard<std::mutex> lk(g_eventMutex);
+    if (g_eventQueue.size() >= g_eventCapacity) {
+        if (g_eventOverflowPolicy == WINUI_EVENT_OVERFLOW_DROP_NEWEST) {
+            g_eventOverflow.fetch_add(1, std::memory_order_relaxed);
+            return;
+        }
+        TrimEventQueueLocked(g_eventCapacity - 1);
+    }
+    g_eventQueue.push_back(ev);
+    g_eventCv.notify_all();
+}
+// Like EnqueueEvent, but overwrites the newest queued event if it has the same
+// kind, so bursts of state events (moves) take a single slot.
+static void EnqueueCoalescedEvent(const WinUIEventInternal& ev){
+    {
+        std::lock_guard<std::mutex> lk(g_eventMutex);
+        if (!g_eventQueue.empty() && g_eventQueue.back().kind == ev.kind) {
+            g_eventQueue.back() = ev;
+            g_eventCv.notify_all();
+            return;
+        }
+    }
+    EnqueueEvent(ev);
 }
 
 
@@ -304,6 +375,113 @@ static std::atomic<int> g_maxClientW{0};
 static std::atomic<int> g_maxClientH{0};
 // Original window proc for subclassing
 static WNDPROC g_originalWndProc = nullptr;
+// Minimize-to-tray: when enabled, SC_MINIMIZE hides the window behind a
+// notification area icon; clicking the icon restores it.
+static std::atomic<bool> g_minimizeToTray{false};
+static bool g_trayIconShown = false; // UI thread only
+static constexpr UINT kTrayCallbackMsg = WM_APP + 1;
+static constexpr UINT kTrayIconId = 1;
+// Raw mouse input: while enabled, relative WM_INPUT mouse motion accumulates
+// here until get_raw_mouse_delta reads and resets it.
+static std::atomic<bool> g_rawMouseEnabled{false};
+static std::atomic<long> g_rawMouseDX{0};
+static std::atomic<long> g_rawMouseDY{0};
+// Custom cursor: when set, replaces the cursor over the client area on every
+// WM_SETCURSOR. Owned by the caller (LoadCursorFromFile on the Go side).
+static std::atomic<HCURSOR> g_customCursor{nullptr};
+// Set by the first WM_SIZING of a modal size/move loop, so a plain move
+// (which also enters the loop) reports no resize start/end.
+static bool g_resizeDragActive = false; // UI thread only
+
+// Custom title bar state; kept so it can be (re)applied once the window exists.
+// UI thread only.
+static bool g_customTitleBar = false;
+static Windows::Graphics::RectInt32 g_titleBarDragRect{ 0, 0, 0, 0 };
+
+// Applies the custom title bar state to the window. WinUI routes pointer input
+// through a child content HWND, so the top-level WM_NCHITTEST never sees the
+// content area; caption behaviour (drag, double-click maximize, snap menu) is
+// declared through AppWindowTitleBar drag rectangles instead.
+static void ApplyTitleBarState() {
+    if (!g_window) return;
+    try {
+        g_window.ExtendsContentIntoTitleBar(g_customTitleBar);
+        if (!g_customTitleBar) return;
+        auto titleBar = g_window.AppWindow().TitleBar();
+        if (g_titleBarDragRect.Width > 0 && g_titleBarDragRect.Height > 0) {
+            titleBar.SetDragRectangles({ g_titleBarDragRect });
+        } else {
+            titleBar.SetDragRectangles({});
+        }
+    } catch(...) {}
+}
+
+// Registers (or removes) the mouse as a raw input device for hwnd. UI thread only.
+static void RegisterRawMouse(HWND hwnd, bool on) {
+    RAWINPUTDEVICE rid{};
+    rid.usUsagePage = 0x01; // HID_USAGE_PAGE_GENERIC
+    rid.usUsage = 0x02;     // HID_USAGE_GENERIC_MOUSE
+    if (on) {
+        if (!hwnd) return;
+        rid.hwndTarget = hwnd;
+    } else {
+        rid.dwFlags = RIDEV_REMOVE;
+    }
+    RegisterRawInputDevices(&rid, 1, sizeof(rid));
+}
+
+static void ShowTrayIcon(HWND hwnd) {
+    if (g_trayIconShown) return;
+    NOTIFYICONDATAW nid{};
+    nid.cbSize = sizeof(nid);
+    nid.hWnd = hwnd;
+    nid.uID = kTrayIconId;
+    nid.uFlags = NIF_MESSAGE | NIF_ICON | NIF_TIP;
+    nid.uCallbackMessage = kTrayCallbackMsg;
+    nid.hIcon = reinterpret_cast<HICON>(SendMessageW(hwnd, WM_GETICON, ICON_SMALL, 0));
+    if (!nid.hIcon) nid.hIcon = reinterpret_cast<HICON>(GetClassLongPtrW(hwnd, GCLP_HICONSM));
+    if (!nid.hIcon) nid.hIcon = LoadIconW(nullptr, IDI_APPLICATION);
+    GetWindowTextW(hwnd, nid.szTip, _countof(nid.szTip));
+    g_trayIconShown = Shell_NotifyIconW(NIM_ADD, &nid) != FALSE;
+}
+
+static void RemoveTrayIcon(HWND hwnd) {
+    if (!g_trayIconShown) return;
+    NOTIFYICONDATAW nid{};
+    nid.cbSize = sizeof(nid);
+    nid.hWnd = hwnd;
+    nid.uID = kTrayIconId;
+    Shell_NotifyIconW(NIM_DELETE, &nid);
+    g_trayIconShown = false;
+}
+
+// Restores a window hidden to the tray and removes the icon.
+static void RestoreFromTray(HWND hwnd) {
+    RemoveTrayIcon(hwnd);
+    ShowWindow(hwnd, SW_RESTORE);
+    SetForegroundWindow(hwnd);
+}
+
+// Taskbar button progress through ITaskbarList3; created on first use.
+static ITaskbarList3* g_taskbarList = nullptr; // UI thread only
+
+static ITaskbarList3* TaskbarList() {
+    if (!g_taskbarList) {
+        ITaskbarList3* p = nullptr;
+        if (SUCCEEDED(CoCreateInstance(CLSID_TaskbarList, nullptr, CLSCTX_INPROC_SERVER, IID_PPV_ARGS(&p)))) {
+            if (SUCCEEDED(p->HrInit())) g_taskbarList = p;
+            else p->Release();
+        }
+    }
+    return g_taskbarList;
+}
+
+static void ReleaseTaskbarList() {
+    if (g_taskbarList) {
+        g_taskbarList->Release();
+        g_taskbarList = nullptr;
+    }
+}
 
 // Forward declarations
 static void ScheduleWindowCreation(int attempt);
@@ -383,6 +561,14 @@ static void AttemptCreateMainWindow(int attempt) {
             if (title.empty()) title = L"Go WinUI Host";
             try { g_window.Title(title); } catch (...) {}
         }
+        // Move before the first show to avoid a flash on the default monitor
+        try {
+            if (g_pendingInitialPosSet.load(std::memory_order_acquire)) {
+                if (auto hwnd = GetWindowHandle()) {
+                    SetWindowPos(hwnd, nullptr, g_pendingInitialX.load(std::memory_order_relaxed), g_pendingInitialY.load(std::memory_order_relaxed), 0, 0, SWP_NOSIZE | SWP_NOZORDER | SWP_NOACTIVATE);
+                }
+            }
+        } catch(...) {}
         g_window.Activate();
         // Focus the root when the window becomes active so it receives KeyDown/Up
         try {
@@ -427,9 +613,76 @@ static void AttemptCreateMainWindow(int attempt) {
                         if (g_originalWndProc) return CallWindowProc(g_originalWndProc, h, msg, w, l);
                         return DefWindowProc(h, msg, w, l);
                     }
+                    if (msg == WM_SETCURSOR && LOWORD(l) == HTCLIENT) {
+                        if (HCURSOR c = g_customCursor.load()) {
+                            SetCursor(c);
+                            return TRUE;
+                        }
+                    }
+                    if (msg == WM_SYSCOMMAND && (w & 0xFFF0) == SC_MINIMIZE && g_minimizeToTray.load()) {
+                        ShowTrayIcon(h);
+                        if (g_trayIconShown) {
+                            ShowWindow(h, SW_HIDE);
+                            return 0;
+                        }
+                    } else if (msg == kTrayCallbackMsg) {
+                        if (LOWORD(l) == WM_LBUTTONUP || LOWORD(l) == WM_LBUTTONDBLCLK) RestoreFromTray(h);
+                        return 0;
+                    } else if (msg == WM_DESTROY) {
+                        WTSUnRegisterSessionNotification(h);
+                        RemoveTrayIcon(h);
+                        ReleaseTaskbarList();
+                    }
+                    if (msg == WM_ACTIVATE) {
+                        int action = LOWORD(w) == WA_INACTIVE ? 2 : 1;
+                        try { EnqueueEvent({6,0,action,0,0,0,0,0}); } catch(...) {}
+                    } else if (msg == WM_DPICHANGED) {
+                        try { EnqueueEvent({7,(int)LOWORD(w),0,0,0,0,0,0}); } catch(...) {}
+                    } else if (msg == WM_DWMCOLORIZATIONCOLORCHANGED) {
+                        try { EnqueueEvent({8,0,0,0,0,0,0,0}); } catch(...) {}
+                    } else if (msg == WM_MOVE) {
+                        RECT rc{};
+                        if (GetWindowRect(h, &rc)) {
+                            try { EnqueueCoalescedEvent({9,0,0,0,(int)rc.left,(int)rc.top,0,0}); } catch(...) {}
+                        }
+                    } else if (msg == WM_SIZING) {
+                        if (!g_resizeDragActive) {
+                            g_resizeDragActive = true;
+                            try { EnqueueEvent({14,0,1,0,0,0,0,0}); } catch(...) {}
+                        }
+                    } else if (msg == WM_EXITSIZEMOVE) {
+                        if (g_resizeDragActive) {
+                            g_resizeDragActive = false;
+                            try { EnqueueEvent({14,0,2,0,0,0,0,0}); } catch(...) {}
+                        }
+                    } else if (msg == WM_DISPLAYCHANGE) {
+                        try { EnqueueCoalescedEvent({13,0,0,0,0,0,0,0}); } catch(...) {}
+                    } else if (msg == WM_WTSSESSION_CHANGE) {
+                        if (w == WTS_SESSION_LOCK || w == WTS_SESSION_UNLOCK) {
+                            int action = w == WTS_SESSION_LOCK ? 1 : 2;
+                            try { EnqueueEvent({10,0,action,0,0,0,0,0}); } catch(...) {}
+                        }
+                    } else if (msg == WM_POWERBROADCAST) {
+                        // PBT_APMRESUMEAUTOMATIC is sent on every resume, PBT_APMRESUMESUSPEND only after user input
+                        if (w == PBT_APMSUSPEND || w == PBT_APMRESUMEAUTOMATIC) {
+                            int action = w == PBT_APMSUSPEND ? 1 : 2;
+                            try { EnqueueEvent({11,0,action,0,0,0,0,0}); } catch(...) {}
+                        }
+                    } else if (msg == WM_INPUT && g_rawMouseEnabled.load(std::memory_order_relaxed)) {
+                        RAWINPUT ri{};
+                        UINT size = sizeof(ri);
+                        if (GetRawInputData(reinterpret_cast<HRAWINPUT>(l), RID_INPUT, &ri, &size, sizeof(RAWINPUTHEADER)) != (UINT)-1
+                            && ri.header.dwType == RIM_TYPEMOUSE && !(ri.data.mouse.usFlags & MOUSE_MOVE_ABSOLUTE)) {
+                            // absolute devices (tablets, remote desktop) report positions, not motion
+                            g_rawMouseDX.fetch_add(ri.data.mouse.lLastX, std::memory_order_relaxed);
+                            g_rawMouseDY.fetch_add(ri.data.mouse.lLastY, std::memory_order_relaxed);
+                        }
+                    }
                     if (g_originalWndProc) return CallWindowProc(g_originalWndProc, h, msg, w, l);
                     return DefWindowProc(h, msg, w, l);
                 }));
+                if (g_rawMouseEnabled.load()) RegisterRawMouse(hwnd, true);
+                WTSRegisterSessionNotification(hwnd, NOTIFY_FOR_THIS_SESSION);
             }
         } catch(...) {}
         // Apply pending initial size if specified before creation.
@@ -449,6 +702,7 @@ static void AttemptCreateMainWindow(int attempt) {
                 }
             }
         } catch(...) {}
+        if (g_customTitleBar) ApplyTitleBarState();
         try { EnqueueEvent({5,0,0,0,0,0,0,0}); } catch(...) {}
         {
             std::lock_guard<std::mutex> lk(g_windowReadyMutex);
@@ -488,37 +742,73 @@ static void AttemptCreateMainWindow(int attempt) {
         root.PointerPressed([](auto&&, Microsoft::UI::Xaml::Input::PointerRoutedEventArgs const& args) {
             auto src = args.OriginalSource().try_as<Microsoft::UI::Xaml::UIElement>();
             auto point = args.GetCurrentPoint(src);
-            int button = 0;
             auto props = point.Properties();
-            if (props.IsLeftButtonPressed()) button = 1;
-            else if (props.IsRightButtonPressed()) button = 2;
-            else if (props.IsMiddleButtonPressed()) button = 3;
-            else if (props.IsXButton1Pressed()) button = 4;
-            else if (props.IsXButton2Pressed()) button = 5;
+            int button = PointerChangedButton(props);
+            if (!button) {
+                if (props.IsLeftButtonPressed()) button = 1;
+                else if (props.IsRightButtonPressed()) button = 2;
+                else if (props.IsMiddleButtonPressed()) button = 3;
+                else if (props.IsXButton1Pressed()) button = 4;
+                else if (props.IsXButton2Pressed()) button = 5;
+            }
             g_lastPointerButton = button;
             int mods = ComputeMods();
-            // Position() returns element-relative (root) pixels; treat as client coordinates.
-            int x = static_cast<int>(point.Position().X);
-            int y = static_cast<int>(point.Position().Y);
+            POINT client = PointerClientPixels(args);
+            int x = client.x;
+            int y = client.y;
             unsigned long long packedXY = (static_cast<unsigned long long>(static_cast<unsigned int>(y)) << 32) | (static_cast<unsigned long long>(static_cast<unsigned int>(x)));
             int codeWithMods = (mods << 16) | (button & 0xFFFF);
             if (g_inputCallback) g_inputCallback(2, codeWithMods, 1, packedXY);
             try { EnqueueEvent({2,button,1,mods,x,y,0,0}); } catch(...) {}
         });
         root.PointerReleased([](auto&&, Microsoft::UI::Xaml::Input::PointerRoutedEventArgs const& args) {
-            auto src = args.OriginalSource().try_as<Microsoft::UI::Xaml::UIElement>();
-            auto point = args.GetCurrentPoint(src);
             int mods = ComputeMods();
-            // Position() returns element-relative (root) pixels; treat as client coordinates.
-            int x = static_cast<int>(point.Position().X);
-            int y = static_cast<int>(point.Position().Y);
-            int button = g_lastPointerButton;
+            POINT client = PointerClientPixels(args);
+            int x = client.x;
+            int y = client.y;
+            // XButton releases must not be reported as the last pressed button
+            int button = 0;
+            try { button = PointerChangedButton(args.GetCurrentPoint(nullptr).Properties()); } catch(...) {}
+            if (!button) button = g_lastPointerButton;
             unsigned long long packedXY = (static_cast<unsigned long long>(static_cast<unsigned int>(y)) << 32) | (static_cast<unsigned long long>(static_cast<unsigned int>(x)));
             int codeWithMods = (mods << 16) | (button & 0xFFFF);
             if (g_inputCallback) g_inputCallback(2, codeWithMods, 2, packedXY);
             g_lastPointerButton = 0;
             try { EnqueueEvent({2,button,2,mods,x,y,0,0}); } catch(...) {}
         });
+        root.PointerWheelChanged([](auto&&, Microsoft::UI::Xaml::Input::PointerRoutedEventArgs const& args) {
+            auto src = args.OriginalSource().try_as<Microsoft::UI::Xaml::UIElement>();
+            auto point = args.GetCurrentPoint(src);
+            auto props = point.Properties();
+            // Horizontal wheel (tilt) is not surfaced yet.
+            if (props.IsHorizontalMouseWheel()) return;
+            int delta = props.MouseWheelDelta(); // multiples of WHEEL_DELTA (120) per notch
+            int mods = ComputeMods();
+            POINT client = PointerClientPixels(args);
+            int x = client.x;
+            int y = client.y;
+            unsigned long long packedXY = (static_cast<unsigned long long>(static_cast<unsigned int>(y)) << 32) | (static_cast<unsigned long long>(static_cast<unsigned int>(x)));
+            // Wheel: action=4, signed delta carried in the low 16 bits of codeWithMods.
+            int codeWithMods = (mods << 16) | (delta & 0xFFFF);
+            if (g_inputCallback) g_inputCallback(2, codeWithMods, 4, packedXY);
+            try { EnqueueEvent({2,delta,4,mods,x,y,0,0}); } catch(...) {}
+        });
+        // Hover: the content lives in a child HWND, so WM_MOUSELEAVE on the top-level
+        // window never fires for the client area; use the root's pointer enter/exit.
+        auto hoverHandler = [](int action) {
+            return [action](auto&&, Microsoft::UI::Xaml::Input::PointerRoutedEventArgs const& args) {
+                int mods = ComputeMods();
+                POINT client = PointerClientPixels(args);
+                int x = client.x;
+                int y = client.y;
+                unsigned long long packedXY = (static_cast<unsigned long long>(static_cast<unsigned int>(y)) << 32) | (static_cast<unsigned long long>(static_cast<unsigned int>(x)));
+                int codeWithMods = (mods << 16);
+                if (g_inputCallback) g_inputCallback(2, codeWithMods, action, packedXY);
+                try { EnqueueEvent({2,0,action,mods,x,y,0,0}); } catch(...) {}
+            };
+        };
+        root.PointerEntered(hoverHandler(5));
+        root.PointerExited(hoverHandler(6));
         // Closed handler: enqueue closed event then start shutdown asynchronously (callback now fired at end of ShutdownUI only).
         g_window.Closed([](auto&&, auto&&) {
             try { EnqueueEvent({4,0,0,0,0,0,0,0}); } catch(...) {}
@@ -657,6 +947,196 @@ static bool IsOnUIThread() {
     return g_uiThreadId != 0 && g_uiThreadId == ::GetCurrentThreadId();
 }
 
+// Runs op on the UI thread: inline when already there, otherwise enqueued (fire-and-forget).
+static void PostToUIThread(std::function<void()> op) {
+    if (IsOnUIThread()) { try { op(); } catch(...) {} return; }
+    if (g_dispatcherQueue) {
+        g_dispatcherQueue.TryEnqueue(Microsoft::UI::Dispatching::DispatcherQueueHandler([op]() {
+            try { op(); } catch(...) {}
+        }));
+    }
+}
+
+// Runs fn on the UI thread and blocks for its result. Returns fallback if the
+// dispatcher is unavailable, the enqueue fails, or fn throws.
+template <typename T, typename F>
+static T InvokeOnUIThreadSync(F fn, T fallback) {
+    if (IsOnUIThread()) {
+        try { return fn(); } catch(...) { return fallback; }
+    }
+    if (!g_dispatcherQueue) return fallback;
+    auto promisePtr = std::make_shared<std::promise<T>>();
+    auto fut = promisePtr->get_future();
+    bool queued = g_dispatcherQueue.TryEnqueue(Microsoft::UI::Dispatching::DispatcherQueueHandler([promisePtr, fn, fallback]() {
+        try { promisePtr->set_value(fn()); } catch(...) { promisePtr->set_value(fallback); }
+    }));
+    if (!queued) return fallback;
+    return fut.get();
+}
+
+// Looks up a registered control; returns nullptr element if unknown. UI thread only.
+static FrameworkElement FindControl(ControlHandle handle) {
+    auto it = g_controls.find(handle);
+    if (it == g_controls.end()) return nullptr;
+    return it->second;
+}
+
+// Adds element to parent (a Panel's children or a ContentControl's content)
+// and registers it. UI thread only. Returns nullptr (with last error set,
+// prefixed by api) for an unknown or unsupported parent.
+static ControlHandle AttachControlOnUI(const wchar_t* api, ControlHandle parent_handle, FrameworkElement const& element) {
+    auto parentFE = FindControl(parent_handle);
+    if (!parentFE) {
+        SetLastErrorInfo(E_INVALIDARG, (std::wstring(api) + L": parent not found").c_str());
+        return nullptr;
+    }
+
+    bool attached = false;
+    if (auto parentPanel = parentFE.try_as<Panel>()) {
+        parentPanel.Children().Append(element);
+        attached = true;
+    } else if (auto parentContent = parentFE.try_as<ContentControl>()) {
+        parentContent.Content(element);
+        attached = true;
+    }
+
+    if (!attached) {
+        SetLastErrorInfo(E_FAIL, (std::wstring(api) + L": unsupported parent type").c_str());
+        return nullptr;
+    }
+
+    ControlHandle handle = reinterpret_cast<ControlHandle>(winrt::get_abi(element));
+    g_controls.insert({ handle, element });
+    return handle;
+}
+
+// Creates a TextBox under parent and registers it. UI thread only; throws on
+// WinRT failure. Returns nullptr (with last error set) for an unusable parent.
+// A multiline box accepts Enter, wraps and scrolls vertically.
+static ControlHandle CreateTextBoxOnUI(ControlHandle parent_handle, const wchar_t* content, bool multiline = false) {
+    Microsoft::UI::Xaml::Controls::TextBox tb;
+    if (content && *content) {
+        tb.Text(content);
+    }
+    if (multiline) {
+        tb.AcceptsReturn(true);
+        tb.TextWrapping(TextWrapping::Wrap);
+        ScrollViewer::SetVerticalScrollBarVisibility(tb, ScrollBarVisibility::Auto);
+        return AttachControlOnUI(L"create_multiline_text_input", parent_handle, tb);
+    }
+    return AttachControlOnUI(L"create_text_input", parent_handle, tb);
+}
+
+// Creates a single-selection ListView of string items under parent. UI thread
+// only; throws on WinRT failure.
+static ControlHandle CreateListViewOnUI(ControlHandle parent_handle) {
+    Microsoft::UI::Xaml::Controls::ListView lv;
+    lv.SelectionMode(ListViewSelectionMode::Single);
+    return AttachControlOnUI(L"create_list_view", parent_handle, lv);
+}
+
+// Creates a 1 DIP divider line using the theme divider brush: horizontal lines
+// stretch across the parent, vertical ones along it. UI thread only.
+static ControlHandle CreateSeparatorOnUI(ControlHandle parent_handle, bool horizontal) {
+    Border line;
+    Microsoft::UI::Xaml::Media::Brush brush{ nullptr };
+    try {
+        auto res = Application::Current().Resources().TryLookup(box_value(L"DividerStrokeColorDefaultBrush"));
+        if (res) brush = res.try_as<Microsoft::UI::Xaml::Media::Brush>();
+    } catch (...) {}
+    if (!brush) brush = Microsoft::UI::Xaml::Media::SolidColorBrush{ Windows::UI::Color{ 0x40, 0x80, 0x80, 0x80 } };
+    line.Background(brush);
+    if (horizontal) {
+        line.Height(1);
+        line.HorizontalAlignment(HorizontalAlignment::Stretch);
+        line.Margin(Thickness{ 0, 4, 0, 4 });
+    } else {
+        line.Width(1);
+        line.VerticalAlignment(VerticalAlignment::Stretch);
+        line.Margin(Thickness{ 4, 0, 4, 0 });
+    }
+    return AttachControlOnUI(L"create_separator", parent_handle, line);
+}
+
+// Creates an empty square of size DIPs; in a StackPanel only the extent along
+// the stacking direction matters. UI thread only.
+static ControlHandle CreateSpacerOnUI(ControlHandle parent_handle, double size) {
+    Border space;
+    space.Width(size);
+    space.Height(size);
+    return AttachControlOnUI(L"create_spacer", parent_handle, space);
+}
+
+// Appends string items to a ListView. UI thread only.
+static bool ListViewAppendOnUI(ControlHandle handle, std::vector<std::wstring> const& items) {
+    auto fe = FindControl(handle);
+    auto lv = fe ? fe.try_as<ListView>() : nullptr;
+    if (!lv) return false;
+    auto list = lv.Items();
+    for (auto const& s : items) list.Append(winrt::box_value(winrt::hstring(s)));
+    return true;
+}
+
+// TextBoxes with a TextChanged handler attached by watch_text_input. UI thread only.
+static std::set<ControlHandle> g_watchedTextInputs;
+// ListViews with a SelectionChanged handler attached by watch_list_view_selection. UI thread only.
+static std::set<ControlHandle> g_watchedListViews;
+// (handle, CONTROL_EVENT_*) pairs attached by watch_control_event. UI thread only.
+static std::set<std::pair<ControlHandle, int>> g_watchedControlEvents;
+
+// Attaches the XAML handler forwarding eventType of the control to
+// g_controlEventCallback. Returns false if the control does not raise it.
+// UI thread only.
+static bool AttachControlEventOnUI(ControlHandle handle, int eventType) {
+    auto fe = FindControl(handle);
+    if (!fe) return false;
+    switch (eventType) {
+    case CONTROL_EVENT_CLICK:
+        if (auto b = fe.try_as<Primitives::ButtonBase>()) {
+            b.Click([handle](auto&&, auto&&) {
+                if (g_controlEventCallback) g_controlEventCallback(handle, CONTROL_EVENT_CLICK, 0, nullptr);
+            });
+            return true;
+        }
+        return false;
+    case CONTROL_EVENT_CHANGED:
+        if (auto tb = fe.try_as<TextBox>()) {
+            tb.TextChanged([handle](auto const& sender, auto&&) {
+                if (!g_controlEventCallback) return;
+                try {
+                    auto text = sender.as<TextBox>().Text();
+                    g_controlEventCallback(handle, CONTROL_EVENT_CHANGED, 0, text.c_str());
+                } catch(...) {}
+            });
+            return true;
+        }
+        return false;
+    case CONTROL_EVENT_SELECTION:
+        if (auto sel = fe.try_as<Primitives::Selector>()) {
+            sel.SelectionChanged([handle](auto const& sender, auto&&) {
+                if (!g_controlEventCallback) return;
+                try {
+                    g_controlEventCallback(handle, CONTROL_EVENT_SELECTION, sender.as<Primitives::Selector>().SelectedIndex(), nullptr);
+                } catch(...) {}
+            });
+            return true;
+        }
+        return false;
+    case CONTROL_EVENT_FOCUS:
+        fe.GotFocus([handle](auto&&, auto&&) {
+            if (g_controlEventCallback) g_controlEventCallback(handle, CONTROL_EVENT_FOCUS, 0, nullptr);
+        });
+        return true;
+    case CONTROL_EVENT_BLUR:
+        fe.LostFocus([handle](auto&&, auto&&) {
+            if (g_controlEventCallback) g_controlEventCallback(handle, CONTROL_EVENT_BLUR, 0, nullptr);
+        });
+        return true;
+    default:
+        return false;
+    }
+}
+
 extern "C" {
 
     ControlHandle __stdcall get_main_window() {
@@ -726,6 +1206,9 @@ extern "C" {
                     // Release WinRT objects on UI thread to avoid cross-thread final release after dispatcher shutdown.
                     g_resizeCallback = nullptr;
                     g_inputCallback = nullptr;
+                    g_textChangedCallback = nullptr;
+                    g_selectionChangedCallback = nullptr;
+                    g_controlEventCallback = nullptr;
                     g_originalRootFE = nullptr;
                     g_overlayText = nullptr;
                     g_overlayRoot = nullptr;
@@ -1087,6 +1570,14 @@ extern "C" {
         return nullptr;
     }
 
+    // Records where the main window first appears; see g_pendingInitialPosSet.
+    // Has no effect once the window exists.
+    void __stdcall set_initial_window_position(int x, int y) {
+        g_pendingInitialX.store(x, std::memory_order_relaxed);
+        g_pendingInitialY.store(y, std::memory_order_relaxed);
+        g_pendingInitialPosSet.store(true, std::memory_order_release);
+    }
+
 
 
 
@@ -1107,37 +1598,10 @@ extern "C" {
 
         auto op = [promisePtr, parent_handle, content]() {
             try {
-                auto it = g_controls.find(parent_handle);
-                if (it == g_controls.end()) {
-                    SetLastErrorInfo(E_INVALIDARG, L"create_text_input: parent not found");
-                    promisePtr->set_value(nullptr);
-                    return;
-                }
-
-                auto parentFE = it->second;
-                Microsoft::UI::Xaml::Controls::TextBox tb;
-                if (content && *content) {
-                    tb.Text(content);
-                }
-
-                bool attached = false;
-                if (auto parentPanel = parentFE.try_as<Panel>()) {
-                    parentPanel.Children().Append(tb);
-                    attached = true;
-                } else if (auto parentContent = parentFE.try_as<ContentControl>()) {
-                    parentContent.Content(tb);
-                    attached = true;
+                ControlHandle handle = CreateTextBoxOnUI(parent_handle, content);
+                if (handle) {
+                    SetLastErrorInfo(S_OK, L"create_text_input succeeded");
                 }
-
-                if (!attached) {
-                    SetLastErrorInfo(E_FAIL, L"create_text_input: unsupported parent type");
-                    promisePtr->set_value(nullptr);
-                    return;
-                }
-
-                ControlHandle handle = reinterpret_cast<ControlHandle>(winrt::get_abi(tb));
-                g_controls.insert({ handle, tb.as<FrameworkElement>() });
-                SetLastErrorInfo(S_OK, L"create_text_input succeeded");
                 promisePtr->set_value(handle);
             } catch (const winrt::hresult_error& e) {
                 std::wstring msg = L"create_text_input failed: ";
@@ -1162,13 +1626,484 @@ extern "C" {
     }
 
 
+    // Control geometry ------------------------------------------------------
+    int __stdcall get_control_bounds(ControlHandle handle, double* x, double* y, double* w, double* h) {
+        if (x) *x = 0; if (y) *y = 0; if (w) *w = 0; if (h) *h = 0;
+        if (!handle || g_shutdownRequested) return 0;
+        struct Bounds { double x, y, w, h; bool ok; };
+        auto b = InvokeOnUIThreadSync([handle]() -> Bounds {
+            auto fe = FindControl(handle);
+            if (!fe || !g_overlayRoot) return { 0, 0, 0, 0, false };
+            double aw = fe.ActualWidth(), ah = fe.ActualHeight();
+            if (aw <= 0 || ah <= 0) return { 0, 0, 0, 0, false }; // not laid out yet
+            auto origin = fe.TransformToVisual(g_overlayRoot).TransformPoint({ 0, 0 });
+            return { origin.X, origin.Y, aw, ah, true };
+        }, Bounds{ 0, 0, 0, 0, false });
+        if (!b.ok) return 0;
+        if (x) *x = b.x; if (y) *y = b.y; if (w) *w = b.w; if (h) *h = b.h;
+        return 1;
+    }
+
+    // Text input change notifications -----------------------------------------
+    void __stdcall register_text_changed_callback(text_changed_callback_t cb) {
+        g_textChangedCallback = cb;
+    }
+
+    // Attaches a TextChanged handler to a TextBox once; later calls for the same handle are no-ops.
+    void __stdcall watch_text_input(ControlHandle handle) {
+        if (!handle || g_shutdownRequested) return;
+        PostToUIThread([handle]() {
+            auto& watched = g_watchedTextInputs;
+            if (watched.count(handle)) return;
+            auto fe = FindControl(handle);
+            if (!fe) return;
+            auto tb = fe.try_as<TextBox>();
+            if (!tb) return;
+            watched.insert(handle);
+            tb.TextChanged([handle](auto const& sender, auto&&) {
+                if (!g_textChangedCallback) return;
+                try {
+                    auto text = sender.as<TextBox>().Text();
+                    g_textChangedCallback(handle, text.c_str());
+                } catch(...) {}
+            });
+        });
+    }
+
+    // Control state ---------------------------------------------------------
+    void __stdcall set_control_visible(ControlHandle handle, int visible) {
+        if (!handle || g_shutdownRequested) return;
+        PostToUIThread([handle, visible]() {
+            if (auto fe = FindControl(handle)) {
+                fe.Visibility(visible ? Visibility::Visible : Visibility::Collapsed);
+            }
+        });
+    }
+
+    void __stdcall set_control_enabled(ControlHandle handle, int enabled) {
+        if (!handle || g_shutdownRequested) return;
+        PostToUIThread([handle, enabled]() {
+            if (auto fe = FindControl(handle)) {
+                if (auto ctrl = fe.try_as<Control>()) ctrl.IsEnabled(enabled != 0);
+            }
+        });
+    }
+
+    int __stdcall is_control_visible(ControlHandle handle) {
+        if (!handle || g_shutdownRequested) return 0;
+        return InvokeOnUIThreadSync([handle]() -> int {
+            auto fe = FindControl(handle);
+            return (fe && fe.Visibility() == Visibility::Visible) ? 1 : 0;
+        }, 0);
+    }
+
+    // Elements that are not Controls (panels, text blocks) report enabled.
+    int __stdcall is_control_enabled(ControlHandle handle) {
+        if (!handle || g_shutdownRequested) return 0;
+        return InvokeOnUIThreadSync([handle]() -> int {
+            auto fe = FindControl(handle);
+            if (!fe) return 0;
+            if (auto ctrl = fe.try_as<Control>()) return ctrl.IsEnabled() ? 1 : 0;
+            return 1;
+        }, 0);
+    }
+
+    // An empty or null text removes the tooltip.
+    void __stdcall set_control_tooltip(ControlHandle handle, const wchar_t* text) {
+        if (!handle || g_shutdownRequested) return;
+        std::wstring copy;
+        try { if (text) copy = text; } catch (...) { return; }
+        PostToUIThread([handle, copy]() {
+            if (auto fe = FindControl(handle)) {
+                if (copy.empty()) ToolTipService::SetToolTip(fe, nullptr);
+                else ToolTipService::SetToolTip(fe, box_value(hstring(copy)));
+            }
+        });
+    }
+
+    // Keyboard focus ------------------------------------------------------------
+    void __stdcall set_control_focus(ControlHandle handle) {
+        if (!handle || g_shutdownRequested) return;
+        PostToUIThread([handle]() {
+            if (auto fe = FindControl(handle)) fe.Focus(FocusState::Programmatic);
+        });
+    }
+
+    ControlHandle __stdcall get_focused_control() {
+        if (g_shutdownRequested) return nullptr;
+        return InvokeOnUIThreadSync([]() -> ControlHandle {
+            if (!g_overlayRoot || !g_overlayRoot.XamlRoot()) return nullptr;
+            auto focused = Microsoft::UI::Xaml::Input::FocusManager::GetFocusedElement(g_overlayRoot.XamlRoot());
+            if (!focused) return nullptr;
+            for (auto const& entry : g_controls) {
+                if (entry.second == focused) return entry.first;
+            }
+            return nullptr;
+        }, static_cast<ControlHandle>(nullptr));
+    }
+
+    // Control enumeration ---------------------------------------------------
+    // Writes up to capacity handles (the main window excluded) to out and
+    // returns the total number, which may exceed capacity.
+    int __stdcall enumerate_controls(ControlHandle* out, int capacity) {
+        if (g_shutdownRequested) return 0;
+        return InvokeOnUIThreadSync([out, capacity]() -> int {
+            ControlHandle windowHandle = g_window ? reinterpret_cast<ControlHandle>(winrt::get_abi(g_window)) : nullptr;
+            int n = 0;
+            for (auto const& entry : g_controls) {
+                if (entry.first == windowHandle) continue;
+                if (out && n < capacity) out[n] = entry.first;
+                ++n;
+            }
+            return n;
+        }, 0);
+    }
+
+    // Copies the unqualified runtime class name ("TextBox", "Button") into buf
+    // (NUL-terminated, truncated to capacity) and returns its full length, or 0
+    // for an unknown handle.
+    int __stdcall get_control_type(ControlHandle handle, wchar_t* buf, int capacity) {
+        if (!handle || g_shutdownRequested) return 0;
+        std::wstring name = InvokeOnUIThreadSync([handle]() -> std::wstring {
+            auto fe = FindControl(handle);
+            if (!fe) return {};
+            std::wstring full{ winrt::get_class_name(fe) };
+            auto dot = full.find_last_of(L'.');
+            return dot == std::wstring::npos ? full : full.substr(dot + 1);
+        }, std::wstring{});
+        if (buf && capacity > 0) {
+            size_t n = (std::min)(name.size(), (size_t)capacity - 1);
+            name.copy(buf, n);
+            buf[n] = L'\0';
+        }
+        return (int)name.size();
+    }
+
+    // Borrowed: no AddRef, so the pointer is only as alive as the registry entry.
+    void* __stdcall get_native_control_pointer(ControlHandle handle) {
+        if (!handle || g_shutdownRequested) return nullptr;
+        return InvokeOnUIThreadSync([handle]() -> void* {
+            auto fe = FindControl(handle);
+            return fe ? winrt::get_abi(fe) : nullptr;
+        }, static_cast<void*>(nullptr));
+    }
+
+    // Multi-line text inputs ----------------------------------------------------
+    ControlHandle __stdcall create_multiline_text_input(ControlHandle parent_handle, const wchar_t* content) {
+        if (!parent_handle || g_shutdownRequested) return nullptr;
+        return InvokeOnUIThreadSync([parent_handle, content]() -> ControlHandle {
+            try {
+                return CreateTextBoxOnUI(parent_handle, content, true);
+            } catch (const winrt::hresult_error& e) {
+                std::wstring msg = L"create_multiline_text_input failed: ";
+                msg += e.message();
+                SetLastErrorInfo(e.code(), msg.c_str());
+                return nullptr;
+            }
+        }, static_cast<ControlHandle>(nullptr));
+    }
+
+    void __stdcall set_text_input_read_only(ControlHandle handle, int readOnly) {
+        if (!handle || g_shutdownRequested) return;
+        PostToUIThread([handle, readOnly]() {
+            if (auto fe = FindControl(handle)) {
+                if (auto tb = fe.try_as<TextBox>()) tb.IsReadOnly(readOnly != 0);
+            }
+        });
+    }
+
+    // List views ----------------------------------------------------------------
+    ControlHandle __stdcall create_list_view(ControlHandle parent_handle) {
+        if (!parent_handle || g_shutdownRequested) return nullptr;
+        return InvokeOnUIThreadSync([parent_handle]() -> ControlHandle {
+            try {
+                return CreateListViewOnUI(parent_handle);
+            } catch (const winrt::hresult_error& e) {
+                std::wstring msg = L"create_list_view failed: ";
+                msg += e.message();
+                SetLastErrorInfo(e.code(), msg.c_str());
+                return nullptr;
+            }
+        }, static_cast<ControlHandle>(nullptr));
+    }
+
+    // Copies the strings before returning, then appends them asynchronously in
+    // one UI-thread operation.
+    void __stdcall list_view_add_items(ControlHandle handle, const wchar_t* const* items, int count) {
+        if (!handle || !items || count <= 0 || g_shutdownRequested) return;
+        std::vector<std::wstring> copy;
+        copy.reserve(count);
+        for (int i = 0; i < count; ++i) copy.emplace_back(items[i] ? items[i] : L"");
+        PostToUIThread([handle, copy = std::move(copy)]() { ListViewAppendOnUI(handle, copy); });
+    }
+
+    void __stdcall list_view_clear(ControlHandle handle) {
+        if (!handle || g_shutdownRequested) return;
+        PostToUIThread([handle]() {
+            auto fe = FindControl(handle);
+            if (auto lv = fe ? fe.try_as<ListView>() : nullptr) lv.Items().Clear();
+        });
+    }
+
+    int __stdcall list_view_get_selected_index(ControlHandle handle) {
+        if (!handle || g_shutdownRequested) return -1;
+        return InvokeOnUIThreadSync([handle]() -> int {
+            auto fe = FindControl(handle);
+            auto lv = fe ? fe.try_as<ListView>() : nullptr;
+            return lv ? lv.SelectedIndex() : -1;
+        }, -1);
+    }
+
+    void __stdcall register_selection_changed_callback(selection_changed_callback_t cb) {
+        g_selectionChangedCallback = cb;
+    }
+
+    // Attaches a SelectionChanged handler to a ListView once; later calls for the same handle are no-ops.
+    void __stdcall watch_list_view_selection(ControlHandle handle) {
+        if (!handle || g_shutdownRequested) return;
+        PostToUIThread([handle]() {
+            if (g_watchedListViews.count(handle)) return;
+            auto fe = FindControl(handle);
+            auto lv = fe ? fe.try_as<ListView>() : nullptr;
+            if (!lv) return;
+            g_watchedListViews.insert(handle);
+            lv.SelectionChanged([handle](auto const& sender, auto&&) {
+                if (!g_selectionChangedCallback) return;
+                try {
+                    g_selectionChangedCallback(handle, sender.as<ListView>().SelectedIndex());
+                } catch(...) {}
+            });
+        });
+    }
+
+    // Unified control events ----------------------------------------------------
+    void __stdcall register_control_event_callback(control_event_callback_t cb) {
+        g_controlEventCallback = cb;
+    }
+
+    // Attaches the handler for eventType once per control; later calls for the
+    // same pair and events the control does not raise are ignored.
+    void __stdcall watch_control_event(ControlHandle handle, int eventType) {
+        if (!handle || g_shutdownRequested) return;
+        PostToUIThread([handle, eventType]() {
+            auto key = std::make_pair(handle, eventType);
+            if (g_watchedControlEvents.count(key)) return;
+            if (AttachControlEventOnUI(handle, eventType)) g_watchedControlEvents.insert(key);
+        });
+    }
+
+    // Separators and spacers ----------------------------------------------------
+    ControlHandle __stdcall create_separator(ControlHandle parent_handle, int horizontal) {
+        if (!parent_handle || g_shutdownRequested) return nullptr;
+        return InvokeOnUIThreadSync([parent_handle, horizontal]() -> ControlHandle {
+            try {
+                return CreateSeparatorOnUI(parent_handle, horizontal != 0);
+            } catch (const winrt::hresult_error& e) {
+                std::wstring msg = L"create_separator failed: ";
+                msg += e.message();
+                SetLastErrorInfo(e.code(), msg.c_str());
+                return nullptr;
+            }
+        }, static_cast<ControlHandle>(nullptr));
+    }
+
+    ControlHandle __stdcall create_spacer(ControlHandle parent_handle, uint64_t sizeBits) {
+        if (!parent_handle || g_shutdownRequested) return nullptr;
+        double size = *reinterpret_cast<double*>(&sizeBits);
+        if (!(size >= 0)) size = 0; // also rejects NaN
+        return InvokeOnUIThreadSync([parent_handle, size]() -> ControlHandle {
+            try {
+                return CreateSpacerOnUI(parent_handle, size);
+            } catch (const winrt::hresult_error& e) {
+                std::wstring msg = L"create_spacer failed: ";
+                msg += e.message();
+                SetLastErrorInfo(e.code(), msg.c_str());
+                return nullptr;
+            }
+        }, static_cast<ControlHandle>(nullptr));
+    }
+
+    // Content reset ---------------------------------------------------------------
+    // Removes every control created through this API from the root grid and
+    // forgets their handles (the overlay text and the window itself stay).
+    // Returns the number of handles released.
+    int __stdcall clear_window_content() {
+        if (g_shutdownRequested) return 0;
+        return InvokeOnUIThreadSync([]() -> int {
+            if (g_overlayRoot) {
+                auto children = g_overlayRoot.Children();
+                for (int i = static_cast<int>(children.Size()) - 1; i >= 0; --i) {
+                    auto child = children.GetAt(i);
+                    if (g_overlayText && child == g_overlayText) continue;
+                    children.RemoveAt(i);
+                }
+            }
+            ControlHandle windowHandle = g_window ? reinterpret_cast<ControlHandle>(winrt::get_abi(g_window)) : nullptr;
+            int released = 0;
+            for (auto it = g_controls.begin(); it != g_controls.end();) {
+                if (it->first == windowHandle) { ++it; continue; }
+                it = g_controls.erase(it);
+                ++released;
+            }
+            g_watchedTextInputs.clear();
+            g_watchedListViews.clear();
+            g_watchedControlEvents.clear();
+            return released;
+        }, 0);
+    }
+
+    // Minimize to tray ----------------------------------------------------------
+    void __stdcall set_minimize_to_tray(int on) {
+        g_minimizeToTray.store(on != 0);
+        if (on || g_shutdownRequested) return;
+        // Turning it off while hidden brings the window back.
+        PostToUIThread([]() {
+            if (auto hwnd = GetWindowHandle()) {
+                if (g_trayIconShown) RestoreFromTray(hwnd);
+            }
+        });
+    }
+
+    // Raw mouse input ------------------------------------------------------------
+    void __stdcall set_raw_mouse_input(int on) {
+        g_rawMouseEnabled.store(on != 0);
+        if (!on) {
+            g_rawMouseDX.store(0);
+            g_rawMouseDY.store(0);
+        }
+        if (g_shutdownRequested) return;
+        // Before the window exists registration happens at creation instead.
+        PostToUIThread([on]() {
+            if (auto hwnd = GetWindowHandle()) RegisterRawMouse(hwnd, on != 0);
+        });
+    }
+
+    void __stdcall get_raw_mouse_delta(int* dx, int* dy) {
+        long x = g_rawMouseDX.exchange(0);
+        long y = g_rawMouseDY.exchange(0);
+        if (dx) *dx = (int)x;
+        if (dy) *dy = (int)y;
+    }
+
+    // Custom cursor ---------------------------------------------------------------
+    void __stdcall set_custom_cursor(void* cursor) {
+        g_customCursor.store(static_cast<HCURSOR>(cursor));
+        if (g_shutdownRequested) return;
+        // WM_SETCURSOR only arrives on the next pointer move; update the
+        // cursor now if it is already over the client area.
+        PostToUIThread([]() {
+            auto hwnd = GetWindowHandle();
+            POINT pt{};
+            RECT rc{};
+            if (!hwnd || !GetCursorPos(&pt) || !ScreenToClient(hwnd, &pt) || !GetClientRect(hwnd, &rc)) return;
+            if (!PtInRect(&rc, pt)) return;
+            HCURSOR c = g_customCursor.load();
+            SetCursor(c ? c : LoadCursor(nullptr, IDC_ARROW));
+        });
+    }
+
+    // Taskbar progress -----------------------------------------------------------
+    void __stdcall set_taskbar_progress(unsigned long long completed, unsigned long long total) {
+        if (g_shutdownRequested) return;
+        PostToUIThread([completed, total]() {
+            auto hwnd = GetWindowHandle();
+            if (auto tb = hwnd ? TaskbarList() : nullptr) tb->SetProgressValue(hwnd, completed, total);
+        });
+    }
+
+    void __stdcall set_taskbar_progress_state(int state) {
+        if (g_shutdownRequested) return;
+        PostToUIThread([state]() {
+            auto hwnd = GetWindowHandle();
+            if (auto tb = hwnd ? TaskbarList() : nullptr) tb->SetProgressState(hwnd, static_cast<TBPFLAG>(state));
+        });
+    }
+
+    // Custom title bar ----------------------------------------------------------
+    void __stdcall set_custom_title_bar(int on) {
+        if (g_shutdownRequested) return;
+        PostToUIThread([on]() {
+            g_customTitleBar = on != 0;
+            ApplyTitleBarState();
+        });
+    }
+
+    void __stdcall set_title_bar_drag_region(int x, int y, int w, int h) {
+        if (g_shutdownRequested) return;
+        PostToUIThread([x, y, w, h]() {
+            g_titleBarDragRect = { x, y, w, h };
+            ApplyTitleBarState();
+        });
+    }
+
+    int __stdcall get_title_bar_height() {
+        if (g_shutdownRequested) return 0;
+        return InvokeOnUIThreadSync([]() -> int {
+            if (!g_window || !g_customTitleBar) return 0;
+            int height = g_window.AppWindow().TitleBar().Height();
+            if (g_titleBarDragRect.Width > 0 && g_titleBarDragRect.Height > 0) {
+                int bottom = g_titleBarDragRect.Y + g_titleBarDragRect.Height;
+                if (bottom > height) height = bottom;
+            }
+            return height;
+        }, 0);
+    }
+
+    // Batched UI operations ---------------------------------------------------
+    // Applies all ops in a single UI-thread round-trip. Each op's resulting (or
+    // targeted) handle is written to outHandles[i]; ops whose target cannot be
+    // resolved are skipped and leave nullptr. Returns the number of ops applied.
+    int __stdcall apply_ui_batch(const UIBatchOp* ops, int count, ControlHandle* outHandles) {
+        if (!ops || count <= 0 || !outHandles || g_shutdownRequested) return 0;
+        for (int i = 0; i < count; ++i) outHandles[i] = nullptr;
+        return InvokeOnUIThreadSync([ops, count, outHandles]() -> int {
+            int applied = 0;
+            for (int i = 0; i < count; ++i) {
+                const auto& op = ops[i];
+                ControlHandle target = op.handle;
+                if (op.ref >= 0) target = op.ref < i ? outHandles[op.ref] : nullptr;
+                if (!target) continue;
+                try {
+                    switch (op.op) {
+                    case UI_BATCH_CREATE_TEXT_INPUT:
+                        outHandles[i] = CreateTextBoxOnUI(target, op.text);
+                        break;
+                    case UI_BATCH_SET_VISIBLE:
+                        if (auto fe = FindControl(target)) {
+                            fe.Visibility(op.value ? Visibility::Visible : Visibility::Collapsed);
+                            outHandles[i] = target;
+                        }
+                        break;
+                    case UI_BATCH_CREATE_LIST_VIEW:
+                        outHandles[i] = CreateListViewOnUI(target);
+                        break;
+                    case UI_BATCH_LIST_VIEW_ADD_ITEM:
+                        if (ListViewAppendOnUI(target, { op.text ? op.text : L"" })) outHandles[i] = target;
+                        break;
+                    case UI_BATCH_SET_ENABLED:
+                        if (auto fe = FindControl(target)) {
+                            if (auto ctrl = fe.try_as<Control>()) ctrl.IsEnabled(op.value != 0);
+                            outHandles[i] = target;
+                        }
+                        break;
+                    default:
+                        break;
+                    }
+                } catch (...) {
+                    outHandles[i] = nullptr;
+                }
+                if (outHandles[i]) ++applied;
+            }
+            return applied;
+        }, 0);
+    }
+
     int __stdcall winui_poll_events(WinUIEvent* outEvents, int max, int* more) {
         if (!outEvents || max <= 0) { if (more) *more = 0; return 0; }
         int count = 0;
-        int tail = g_eventTail.load(std::memory_order_acquire);
-        int head = g_eventHead.load(std::memory_order_acquire);
-        while (tail != head && count < max) {
-            auto &src = g_eventRing[tail];
+        std::lock_guard<std::mutex> lk(g_eventMutex);
+        while (!g_eventQueue.empty() && count < max) {
+            auto &src = g_eventQueue.front();
             outEvents[count].kind = src.kind;
             outEvents[count].code = src.code;
             outEvents[count].action = src.action;
@@ -1178,14 +2113,40 @@ extern "C" {
             outEvents[count].w = src.w;
             outEvents[count].h = src.h;
             ++count;
-            tail = (tail + 1) % kEventRingSize;
+            g_eventQueue.pop_front();
         }
-        g_eventTail.store(tail, std::memory_order_release);
-        int newHead = g_eventHead.load(std::memory_order_acquire);
-        if (more) *more = (tail != newHead) ? 1 : 0;
+        if (more) *more = g_eventQueue.empty() ? 0 : 1;
         return count;
     }
 
+    int __stdcall winui_wait_for_events(int timeoutMs) {
+        std::unique_lock<std::mutex> lk(g_eventMutex);
+        auto ready = []{ return !g_eventQueue.empty(); };
+        if (timeoutMs < 0) { g_eventCv.wait(lk, ready); return 1; }
+        return g_eventCv.wait_for(lk, std::chrono::milliseconds(timeoutMs), ready) ? 1 : 0;
+    }
+
+    void __stdcall winui_post_wake_event() {
+        try { EnqueueCoalescedEvent({12,0,0,0,0,0,0,0}); } catch(...) {}
+    }
+
+    void __stdcall winui_set_event_queue_capacity(int capacity) {
+        if (capacity < 1) capacity = 1;
+        std::lock_guard<std::mutex> lk(g_eventMutex);
+        g_eventCapacity = static_cast<size_t>(capacity);
+        TrimEventQueueLocked(g_eventCapacity);
+    }
+
+    void __stdcall winui_set_event_overflow_policy(int policy) {
+        if (policy != WINUI_EVENT_OVERFLOW_DROP_OLDEST && policy != WINUI_EVENT_OVERFLOW_DROP_NEWEST) return;
+        std::lock_guard<std::mutex> lk(g_eventMutex);
+        g_eventOverflowPolicy = policy;
+    }
+
+    unsigned long long __stdcall winui_get_dropped_event_count() {
+        return g_eventOverflow.load(std::memory_order_relaxed);
+    }
+
 } // extern "C"
 
 static void DeferredBootstrapShutdown(){wchar_t enableBuf[8];if(GetEnvironmentVariableW(L"WINUI_ENABLE_BOOTSTRAP_SHUTDOWN",enableBuf,_countof(enableBuf))==0)return; if(g_bootstrapVersion){try{OutputDebugStringW(L"[Bootstrap] DeferredBootstrapShutdown begin (opt-in)\n");}catch(...){} try{MddBootstrapShutdown();OutputDebugStringW(L"[Bootstrap] DeferredBootstrapShutdown complete (opt-in)\n");}catch(...){try{OutputDebugStringW(L"[Bootstrap] DeferredBootstrapShutdown exception (opt-in)\n");}catch(...){} }} }
@@ -27,6 +27,9 @@ extern "C" {
     // Creates (or schedules) the main window with an initial client size (width,height)
     // and title. Width/height <=0 can fall back to defaults decided by the native layer.
     WINUI3NATIVE_API ControlHandle __stdcall create_window(int width, int height, const wchar_t* title);
+    // Outer top-left position (screen pixels) applied before the window is first
+    // shown. Call before create_window; ignored once the window exists.
+    WINUI3NATIVE_API void __stdcall set_initial_window_position(int x, int y);
     // (Removed: create_container / control creation / modifier / diagnostics exports per request)
     WINUI3NATIVE_API ControlHandle __stdcall get_main_window();
     WINUI3NATIVE_API int __stdcall window_exists();
@@ -48,7 +51,8 @@ extern "C" {
     // input_event_callback_t packed parameters:
     // kind: 1=key 2=mouse
     // codeWithMods: low 16 bits = virtual key or mouse button id; high 16 bits = mods bitmask
-    // action: 1=down/press 2=up/release (keys & mouse)
+    // action: 1=down/press 2=up/release (keys & mouse) 4=wheel (mouse; low 16 bits of
+    //         codeWithMods carry the signed wheel delta instead of a button id)
     // packedXY: lower 32 bits = x, upper 32 bits = y (client coordinates). For key events x=y=0.
     typedef void(__stdcall* input_event_callback_t)(int kind, int codeWithMods, int action, unsigned long long packedXY);
     WINUI3NATIVE_API void __stdcall register_input_callback(input_event_callback_t cb);
@@ -62,17 +66,169 @@ extern "C" {
     // These are enforced via WM_GETMINMAXINFO by adjusting to outer window size.
     WINUI3NATIVE_API void __stdcall set_window_min_max(int minW, int minH, int maxW, int maxH);
 
+    // Control state. Setters are marshalled to the UI thread asynchronously;
+    // getters block until the UI thread answers. Unknown handles are ignored (getters return 0).
+    WINUI3NATIVE_API void __stdcall set_control_visible(ControlHandle handle, int visible);
+    WINUI3NATIVE_API void __stdcall set_control_enabled(ControlHandle handle, int enabled);
+    WINUI3NATIVE_API int __stdcall is_control_visible(ControlHandle handle);
+    WINUI3NATIVE_API int __stdcall is_control_enabled(ControlHandle handle);
+    // Hover tooltip (ToolTipService.ToolTip); an empty or null text removes it.
+    WINUI3NATIVE_API void __stdcall set_control_tooltip(ControlHandle handle, const wchar_t* text);
+
+    // Keyboard focus. set_control_focus moves focus to the control (programmatic
+    // focus state); get_focused_control returns the registered control that has
+    // focus, or nullptr if focus is elsewhere or on an element not created here.
+    WINUI3NATIVE_API void __stdcall set_control_focus(ControlHandle handle);
+    WINUI3NATIVE_API ControlHandle __stdcall get_focused_control();
+
+    // Control enumeration. enumerate_controls writes up to capacity live handles
+    // (all registered controls except the main window) to out and returns the
+    // total count; call it with capacity 0 to size the buffer. get_control_type
+    // copies the unqualified XAML class name ("TextBox") into buf and returns its
+    // length, or 0 for an unknown handle.
+    WINUI3NATIVE_API int __stdcall enumerate_controls(ControlHandle* out, int capacity);
+    WINUI3NATIVE_API int __stdcall get_control_type(ControlHandle handle, wchar_t* buf, int capacity);
+    // Raw IInspectable* (the IFrameworkElement interface) behind a handle for
+    // interop, or nullptr for an unknown handle. Not AddRef'd: do not Release it,
+    // and AddRef or QueryInterface it to keep it past the control's removal.
+    WINUI3NATIVE_API void* __stdcall get_native_control_pointer(ControlHandle handle);
+
+    // Control position and size in DIPs relative to the window content. Returns 0
+    // (and zeros) for unknown handles or controls that have not been laid out yet.
+    WINUI3NATIVE_API int __stdcall get_control_bounds(ControlHandle handle, double* x, double* y, double* w, double* h);
+
+    // TextBox change notifications. The callback fires on the UI thread after every
+    // edit with the control handle and the current text (valid only during the call).
+    // watch_text_input must be called once per TextBox to start delivering events.
+    typedef void(__stdcall* text_changed_callback_t)(ControlHandle handle, const wchar_t* text);
+    WINUI3NATIVE_API void __stdcall register_text_changed_callback(text_changed_callback_t cb);
+    WINUI3NATIVE_API void __stdcall watch_text_input(ControlHandle handle);
+    // Multi-line TextBox (Enter inserts a newline, text wraps, vertical scroll bar
+    // on demand); blocks until created. set_text_input_read_only is asynchronous
+    // and ignores handles that are not TextBoxes.
+    WINUI3NATIVE_API ControlHandle __stdcall create_multiline_text_input(ControlHandle parent_handle, const wchar_t* content);
+    WINUI3NATIVE_API void __stdcall set_text_input_read_only(ControlHandle handle, int readOnly);
+
+    // ListView of string items (single selection). list_view_add_items copies the
+    // strings before returning and appends them in one UI-thread operation;
+    // add/clear are asynchronous, create and get_selected_index block.
+    // get_selected_index returns -1 for no selection or an unknown handle.
+    WINUI3NATIVE_API ControlHandle __stdcall create_list_view(ControlHandle parent_handle);
+    WINUI3NATIVE_API void __stdcall list_view_add_items(ControlHandle handle, const wchar_t* const* items, int count);
+    WINUI3NATIVE_API void __stdcall list_view_clear(ControlHandle handle);
+    WINUI3NATIVE_API int __stdcall list_view_get_selected_index(ControlHandle handle);
+    // Selection notifications, delivered on the UI thread with the new index (-1
+    // when cleared). watch_list_view_selection must be called once per ListView.
+    typedef void(__stdcall* selection_changed_callback_t)(ControlHandle handle, int index);
+    WINUI3NATIVE_API void __stdcall register_selection_changed_callback(selection_changed_callback_t cb);
+    WINUI3NATIVE_API void __stdcall watch_list_view_selection(ControlHandle handle);
+
+    // Layout helpers for StackPanel forms. create_separator adds a 1 DIP theme
+    // divider (horizontal != 0 for a horizontal line); create_spacer adds empty
+    // space of the given size in DIPs, passed as IEEE-754 bits like the resize
+    // callback.
+    WINUI3NATIVE_API ControlHandle __stdcall create_separator(ControlHandle parent_handle, int horizontal);
+    WINUI3NATIVE_API ControlHandle __stdcall create_spacer(ControlHandle parent_handle, uint64_t sizeBits);
+
+    // Unified control events. One callback receives every watched event on the
+    // UI thread as (handle, eventType, value, text); text is valid only during
+    // the call. watch_control_event must be called once per control and event
+    // type; events a control does not raise are ignored. Supersedes the
+    // per-type callbacks above, which remain for older clients.
+    enum {
+        CONTROL_EVENT_CLICK = 1,     // ButtonBase.Click; no payload
+        CONTROL_EVENT_CHANGED = 2,   // TextBox.TextChanged; text = current text
+        CONTROL_EVENT_SELECTION = 3, // Selector.SelectionChanged; value = selected index (-1 none)
+        CONTROL_EVENT_FOCUS = 4,     // UIElement.GotFocus (bubbles from descendants); no payload
+        CONTROL_EVENT_BLUR = 5,      // UIElement.LostFocus (bubbles from descendants); no payload
+    };
+    typedef void(__stdcall* control_event_callback_t)(ControlHandle handle, int eventType, int value, const wchar_t* text);
+    WINUI3NATIVE_API void __stdcall register_control_event_callback(control_event_callback_t cb);
+    WINUI3NATIVE_API void __stdcall watch_control_event(ControlHandle handle, int eventType);
+
+    // Removes all controls created through this API from the window content and
+    // invalidates their handles. Blocks until the UI thread has finished.
+    // Returns the number of handles released.
+    WINUI3NATIVE_API int __stdcall clear_window_content();
+
+    // Minimize to tray: while on, minimizing hides the window and shows a
+    // notification area icon (window icon and title); clicking it restores the
+    // window. Turning it off restores a hidden window. The icon is removed on close.
+    WINUI3NATIVE_API void __stdcall set_minimize_to_tray(int on);
+
+    // Raw mouse input (WM_INPUT). While on, relative motion from the mouse
+    // accumulates in device units, unaffected by pointer speed, acceleration or
+    // the screen edge, and only while the window is in the foreground.
+    // get_raw_mouse_delta returns the motion since the previous call and resets
+    // it. May be called before the window exists.
+    WINUI3NATIVE_API void __stdcall set_raw_mouse_input(int on);
+    WINUI3NATIVE_API void __stdcall get_raw_mouse_delta(int* dx, int* dy);
+
+    // Custom cursor: cursor (an HCURSOR owned by the caller) replaces the
+    // cursor over the client area until changed; nullptr restores the default.
+    // XAML elements that set their own cursor (text boxes) may still show theirs.
+    WINUI3NATIVE_API void __stdcall set_custom_cursor(void* cursor);
+
+    // Taskbar button progress (ITaskbarList3). set_taskbar_progress shows
+    // completed/total and switches a hidden or indeterminate bar to normal;
+    // set_taskbar_progress_state takes a TBPFLAG value (0 none, 1 indeterminate,
+    // 2 normal, 4 error, 8 paused).
+    WINUI3NATIVE_API void __stdcall set_taskbar_progress(unsigned long long completed, unsigned long long total);
+    WINUI3NATIVE_API void __stdcall set_taskbar_progress_state(int state);
+
+    // Custom title bar. set_custom_title_bar extends the content into the title
+    // bar area (the system caption buttons stay). set_title_bar_drag_region marks
+    // a client-pixel rectangle that behaves like the caption (drag, double-click
+    // maximize); w or h <= 0 clears it. Both may be called before the window exists.
+    WINUI3NATIVE_API void __stdcall set_custom_title_bar(int on);
+    WINUI3NATIVE_API void __stdcall set_title_bar_drag_region(int x, int y, int w, int h);
+    // get_title_bar_height returns the client pixels covered by the custom title
+    // bar: the system caption height or the bottom of the drag region, whichever
+    // is larger. 0 when the custom title bar is off or the window does not exist.
+    WINUI3NATIVE_API int __stdcall get_title_bar_height();
+
+    // Batched UI operations: applies count ops in one UI-thread round-trip instead
+    // of one per call. ref >= 0 targets the control produced by an earlier op in
+    // the same batch (creations use it as parent); ref < 0 uses handle.
+    // outHandles[i] receives the created or targeted control (nullptr if skipped).
+    enum {
+        UI_BATCH_CREATE_TEXT_INPUT = 1,  // text = initial content
+        UI_BATCH_SET_VISIBLE = 2,        // value = 0/1
+        UI_BATCH_SET_ENABLED = 3,        // value = 0/1
+        UI_BATCH_CREATE_LIST_VIEW = 4,   // target = parent
+        UI_BATCH_LIST_VIEW_ADD_ITEM = 5, // text = item
+    };
+    typedef struct UIBatchOp {
+        int            op;
+        int            ref;
+        ControlHandle  handle;
+        const wchar_t* text;
+        int            value;
+        int            reserved;
+    } UIBatchOp;
+    WINUI3NATIVE_API int __stdcall apply_ui_batch(const UIBatchOp* ops, int count, ControlHandle* outHandles);
+
     // Overlay / HUD utilities
     // Sets (or creates) a centered overlay TextBlock showing provided text.
     // Passing an empty string hides it.
     // (Removed: set_center_overlay_text per request)
 
     // Unified event system (polled from Go side)
-    // kind:1=key 2=mouse 3=resize 4=window_closed 5=window_created
+    // kind:1=key 2=mouse 3=resize 4=window_closed 5=window_created 6=focus 7=dpi_changed 8=accent_color_changed 9=moved 10=session 11=power 12=wake 13=display_changed 14=resize_drag
     // key: code=vk action:1=down 2=up mods=bitmask (side specific)
     // mouse: code=button(1..5) action:1=down 2=up x,y client coords mods=bitmask
+    //        wheel: action=4 code=signed delta (120 per notch)
+    //        hover: action=5 entered / 6 left the client area (code unused)
     // resize: w,h populated (action/code unused)
     // window_closed/window_created: no extra fields
+    // focus: action:1=gained 2=lost (window activation)
+    // dpi_changed: code=new DPI (96 = 100%)
+    // moved: x,y = outer window origin in screen coords; consecutive moves are coalesced
+    // session: action:1=locked 2=unlocked (WM_WTSSESSION_CHANGE)
+    // power: action:1=suspending 2=resumed (WM_POWERBROADCAST)
+    // wake: posted by winui_post_wake_event, no fields
+    // display_changed: resolution or rotation changed (WM_DISPLAYCHANGE), no fields; coalesced
+    // resize_drag: action:1=started (first WM_SIZING) 2=ended (WM_EXITSIZEMOVE) for user border resizes
     typedef struct WinUIEvent {
         int   kind;
         int   code;
@@ -87,4 +243,25 @@ extern "C" {
     // Poll up to max events into outEvents. Returns number copied.
     // If *more is set to 1 after return, additional events remain.
     WINUI3NATIVE_API int __stdcall winui_poll_events(WinUIEvent* outEvents, int max, int* more);
+
+    // Blocks until at least one event is queued or timeoutMs elapses (negative
+    // waits forever). Returns 1 if events are pending, 0 on timeout. Events are
+    // not consumed; call winui_poll_events afterwards.
+    WINUI3NATIVE_API int __stdcall winui_wait_for_events(int timeoutMs);
+    // Queues a wake event so a pending winui_wait_for_events returns. Repeated
+    // calls before the next poll share one queue slot.
+    WINUI3NATIVE_API void __stdcall winui_post_wake_event();
+
+    // Event queue limits. When the queue is full the overflow policy decides
+    // which event is dropped; every dropped event is counted.
+    enum {
+        WINUI_EVENT_OVERFLOW_DROP_OLDEST = 0, // default
+        WINUI_EVENT_OVERFLOW_DROP_NEWEST = 1,
+    };
+    // Sets the maximum number of queued events (default 255, minimum 1).
+    // Shrinking below the current backlog drops events per the policy.
+    WINUI3NATIVE_API void __stdcall winui_set_event_queue_capacity(int capacity);
+    WINUI3NATIVE_API void __stdcall winui_set_event_overflow_policy(int policy);
+    // Total events dropped since load.
+    WINUI3NATIVE_API unsigned long long __stdcall winui_get_dropped_event_count();
 }
\ No newline at end of file